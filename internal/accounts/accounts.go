@@ -48,7 +48,15 @@ func init() {
 	createCommand.AddToParent(Cmd)
 	stakingCommand.AddToParent(Cmd)
 	getCommand.AddToParent(Cmd)
+	diffCommand.AddToParent(Cmd)
+	exportCommand.AddToParent(Cmd)
+	importCommand.AddToParent(Cmd)
 	fundCommand.AddToParent(Cmd)
+	transferCommand.AddToParent(Cmd)
+	nftsCommand.AddToParent(Cmd)
+	historyCommand.AddToParent(Cmd)
+	Cmd.AddCommand(keysCmd)
+	Cmd.AddCommand(stakingCmd)
 }
 
 // accountResult represent result from all account commands.
@@ -19,10 +19,15 @@
 package accounts
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/onflow/cadence"
 	"github.com/onflow/flowkit/accounts"
 
 	"github.com/onflow/flow-go-sdk"
@@ -32,9 +37,13 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/config"
+	"github.com/onflow/flowkit/mocks"
 	"github.com/onflow/flowkit/tests"
+	"github.com/onflow/flowkit/transactions"
 
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/keybackend"
 	"github.com/onflow/flow-cli/internal/util"
 )
 
@@ -247,6 +256,58 @@ func Test_Create(t *testing.T) {
 		_, err := parseSignatureAlgorithms([]string{"invalid"})
 		assert.EqualError(t, err, "invalid signature algorithm: invalid")
 	})
+
+	t.Run("Success from spec", func(t *testing.T) {
+		spec := `
+name: operations
+keys:
+  - name: alice
+    publicKey: 014d91eb68b5fddeca118821e74f70b48d9582c8546d8a2ae9d6835cdb7d1d008624945f55c4b409c628b63a89a54570ed028e8e68a1fe0c98ef08d7f488037b
+    weight: 500
+  - name: bob
+    publicKey: c4bcde70e3c29cdc472ce7be46e219ca42f0ed2174369b3ba693c5655ed03f7027c571ba3881ed4b480fba41760572bcc167a8dbcf4e6ed952dcce831f82fc92
+    sigAlgo: ECDSA_secp256k1
+    weight: 500
+  - name: local
+    generate: true
+    weight: 500
+`
+		require.NoError(t, state.ReaderWriter().WriteFile("account.yaml", []byte(spec), 0644))
+		createFlags.FromSpec = "account.yaml"
+
+		privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, []byte(strings.Repeat("s", crypto.MinSeedLength)))
+		require.NoError(t, err)
+		srv.Mock.On("GenerateKey", mock.Anything, mock.Anything, mock.Anything).Return(privateKey, nil)
+
+		srv.CreateAccount.Run(func(args mock.Arguments) {
+			keys := args.Get(2).([]accounts.PublicKey)
+			assert.Len(t, keys, 3)
+			assert.Equal(t, crypto.ECDSA_secp256k1, keys[1].SigAlgo)
+			assert.Equal(t, 500, keys[2].Weight)
+		})
+
+		result, err := create([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		createFlags.FromSpec = ""
+		require.NoError(t, err)
+		require.NotNil(t, result)
+
+		account, err := state.Accounts().ByName("operations")
+		require.NoError(t, err)
+		assert.Equal(t, 2, account.Key.Index())
+		_, err = state.ReaderWriter().ReadFile("operations.pkey")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Fail spec below threshold", func(t *testing.T) {
+		_, err := parseAccountSpec([]byte(`
+name: operations
+keys:
+  - name: local
+    generate: true
+    weight: 500
+`))
+		assert.EqualError(t, err, "total key weight 500 is below the threshold of 1000 required to sign transactions")
+	})
 }
 
 func Test_Get(t *testing.T) {
@@ -267,6 +328,646 @@ func Test_Get(t *testing.T) {
 	})
 }
 
+func Test_Keys(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	srv.SendTransaction.Return(tests.NewTransaction(), tests.NewTransactionResult(nil), nil)
+
+	t.Run("Success add", func(t *testing.T) {
+		pkey := "014d91eb68b5fddeca118821e74f70b48d9582c8546d8a2ae9d6835cdb7d1d008624945f55c4b409c628b63a89a54570ed028e8e68a1fe0c98ef08d7f488037b"
+
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			roles := args.Get(1).(transactions.AccountRoles)
+			assert.Equal(t, "emulator-account", roles.Proposer.Name)
+			script := args.Get(2).(flowkit.Script)
+			assert.Len(t, script.Args, 1)
+			assert.Contains(t, string(script.Code), "signer.keys.add")
+		})
+
+		result, err := addKey([]string{pkey}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+	})
+
+	t.Run("Fail add invalid weight", func(t *testing.T) {
+		addKeyFlags.Weight = 1001
+		_, err := addKey([]string{"invalid"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "invalid key weight 1001, must be between 0 and 1000")
+		addKeyFlags.Weight = 1000
+	})
+
+	t.Run("Success revoke", func(t *testing.T) {
+		account := tests.NewAccountWithAddress("0x01")
+		srv.GetAccount.Run(func(args mock.Arguments) {
+			srv.GetAccount.Return(account, nil)
+		})
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			script := args.Get(2).(flowkit.Script)
+			assert.Equal(t, fmt.Sprintf("%d", account.Keys[1].Index), script.Args[0].String())
+		})
+
+		inArgs := []string{fmt.Sprintf("%d", account.Keys[1].Index)}
+		result, err := revokeKey(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+	})
+
+	t.Run("Fail revoke below threshold", func(t *testing.T) {
+		account := tests.NewAccountWithAddress("0x01")
+		account.Keys[0].Revoked = true
+		srv.GetAccount.Run(func(args mock.Arguments) {
+			srv.GetAccount.Return(account, nil)
+		})
+
+		inArgs := []string{fmt.Sprintf("%d", account.Keys[1].Index)}
+		_, err := revokeKey(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, fmt.Sprintf(
+			"refusing to revoke key %d: remaining key weight 0 would be below the threshold of 1000",
+			account.Keys[1].Index,
+		))
+	})
+
+	t.Run("Fail revoke invalid index", func(t *testing.T) {
+		_, err := revokeKey([]string{"-1"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "invalid key index -1, must be a positive number")
+	})
+}
+
+func Test_RotateKey(t *testing.T) {
+	// accountStates returns the account before and after the new key is added
+	accountStates := func(oldWeight int, privateKey crypto.PrivateKey) (*flow.Account, *flow.Account) {
+		before := tests.NewAccountWithAddress("0x01")
+		before.Keys = []*flow.AccountKey{{
+			Index:     0,
+			PublicKey: tests.PubKeys()[0],
+			SigAlgo:   crypto.ECDSA_P256,
+			HashAlgo:  crypto.SHA3_256,
+			Weight:    oldWeight,
+		}}
+		after := *before
+		after.Keys = append([]*flow.AccountKey{}, before.Keys...)
+		after.Keys = append(after.Keys, &flow.AccountKey{
+			Index:     1,
+			PublicKey: privateKey.PublicKey(),
+			SigAlgo:   crypto.ECDSA_P256,
+			HashAlgo:  crypto.SHA3_256,
+			Weight:    oldWeight,
+		})
+		return before, &after
+	}
+
+	setup := func(t *testing.T, oldWeight int) (*flowkit.State, *mocks.MockServices, *[]string, string) {
+		srv, state, _ := util.TestMocks(t)
+		rotateKeyFlags.Signer = "emulator-account"
+
+		privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, make([]byte, crypto.MinSeedLength))
+		require.NoError(t, err)
+		before, after := accountStates(oldWeight, privateKey)
+
+		added := false
+		srv.GetAccount.Run(func(args mock.Arguments) {
+			if added {
+				srv.GetAccount.Return(after, nil)
+			} else {
+				srv.GetAccount.Return(before, nil)
+			}
+		})
+		srv.Mock.
+			On("GenerateKey", mock.Anything, mock.Anything, mock.Anything).
+			Return(privateKey, nil)
+
+		var sent []string
+		srv.SendTransaction.Return(func(
+			_ context.Context,
+			roles transactions.AccountRoles,
+			script flowkit.Script,
+			_ uint64,
+		) (*flow.Transaction, *flow.TransactionResult, error) {
+			code := string(script.Code)
+			if strings.Contains(code, "keys.add") {
+				added = true
+				sent = append(sent, "add")
+			} else {
+				// the revoke transaction is signed with the new key, so the configuration must be updated first
+				account, err := state.Accounts().ByName("emulator-account")
+				require.NoError(t, err)
+				assert.Equal(t, 1, account.Key.Index())
+				assert.Equal(t, 1, roles.Proposer.Key.Index())
+				sent = append(sent, fmt.Sprintf("revoke %s", script.Args[0]))
+			}
+			return tests.NewTransaction(), tests.NewTransactionResult(nil), nil
+		})
+
+		return state, srv, &sent, privateKey.String()
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		state, srv, sent, newPrivateKey := setup(t, flow.AccountKeyWeightThreshold)
+		oldLocation := "emulator-account.pkey"
+		oldPrivateKey, err := state.ReaderWriter().ReadFile(oldLocation)
+		require.NoError(t, err)
+
+		result, err := rotateKey([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, []string{"add", "revoke 0"}, *sent)
+
+		account, err := state.Accounts().ByName("emulator-account")
+		require.NoError(t, err)
+		assert.Equal(t, 1, account.Key.Index())
+		assert.Equal(t, "emulator-account-1.pkey", account.Key.ToConfig().Location)
+
+		newKey, err := state.ReaderWriter().ReadFile("emulator-account-1.pkey")
+		require.NoError(t, err)
+		assert.Equal(t, newPrivateKey, string(newKey))
+
+		unchanged, err := state.ReaderWriter().ReadFile(oldLocation)
+		require.NoError(t, err)
+		assert.Equal(t, oldPrivateKey, unchanged)
+	})
+
+	t.Run("Fail below threshold", func(t *testing.T) {
+		state, srv, sent, _ := setup(t, 500)
+
+		_, err := rotateKey([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "refusing to revoke key 0: remaining key weight 500 would be below the threshold of 1000")
+		assert.Empty(t, *sent)
+
+		_, err = state.ReaderWriter().ReadFile("emulator-account-1.pkey")
+		assert.Error(t, err)
+	})
+
+	t.Run("Fail unsupported key type", func(t *testing.T) {
+		state, srv, sent, _ := setup(t, flow.AccountKeyWeightThreshold)
+		state.Accounts().AddOrUpdate(&accounts.Account{
+			Name:    "emulator-account",
+			Address: flow.HexToAddress("0x01"),
+			Key:     keybackend.NewKey("emulator-account.key", 0, crypto.ECDSA_P256, crypto.SHA3_256, nil),
+		})
+
+		_, err := rotateKey([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "rotating the key of account emulator-account is not supported, only hex keys and private key files can be rotated")
+		assert.Empty(t, *sent)
+	})
+}
+
+func Test_Transfer(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	srv.SendTransaction.Return(tests.NewTransaction(), tests.NewTransactionResult(nil), nil)
+
+	balance, _ := cadence.NewUFix64("100.0")
+	srv.ExecuteScript.Run(func(args mock.Arguments) {
+		script := args.Get(1).(flowkit.Script)
+		if strings.Contains(string(script.Code), "FungibleToken.Receiver") {
+			srv.ExecuteScript.Return(cadence.NewBool(true), nil)
+			return
+		}
+		srv.ExecuteScript.Return(cadence.NewOptional(balance), nil)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			script := args.Get(2).(flowkit.Script)
+			assert.Contains(t, string(script.Code), "import FungibleToken from 0xee82856bf20e2aa6")
+			assert.Equal(t, "10.00000000", script.Args[0].String())
+			assert.Equal(t, "0x0000000000000001", script.Args[1].String())
+			assert.Equal(t, `"flowTokenVault"`, script.Args[2].String())
+			assert.Equal(t, `"A.0ae53cb6e3f42a79.FlowToken.Vault"`, script.Args[4].String())
+		})
+
+		result, err := transfer([]string{"10", "0x01"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+	})
+
+	t.Run("Fail insufficient balance", func(t *testing.T) {
+		_, err := transfer([]string{"1000", "0x01"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "insufficient balance, account 0xf8d6e0586b0a20c7 has 100.00000000 FlowToken but 1000.00000000 is required")
+	})
+
+	t.Run("Fail missing paths", func(t *testing.T) {
+		transferFlags.Token = "0x01.ExampleToken"
+		_, err := transfer([]string{"10", "0x01"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "vault path and receiver path flags must be provided for token ExampleToken")
+		transferFlags.Token = "FlowToken"
+	})
+}
+
+func Test_NFTs(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+
+	t.Run("Success list", func(t *testing.T) {
+		nftType := cadence.NewStructType(nil, "NFTInfo", []cadence.Field{
+			{Identifier: "id", Type: cadence.UInt64Type{}},
+			{Identifier: "name", Type: cadence.NewOptionalType(cadence.StringType{})},
+			{Identifier: "thumbnail", Type: cadence.NewOptionalType(cadence.StringType{})},
+		}, nil)
+		collectionType := cadence.NewStructType(nil, "CollectionInfo", []cadence.Field{
+			{Identifier: "path", Type: cadence.StringType{}},
+			{Identifier: "typeIdentifier", Type: cadence.StringType{}},
+			{Identifier: "nfts", Type: cadence.NewVariableSizedArrayType(nftType)},
+		}, nil)
+
+		srv.ExecuteScript.Run(func(args mock.Arguments) {
+			script := args.Get(1).(flowkit.Script)
+			assert.Contains(t, string(script.Code), "import NonFungibleToken from 0xf8d6e0586b0a20c7")
+			srv.ExecuteScript.Return(cadence.NewArray([]cadence.Value{
+				cadence.NewStruct([]cadence.Value{
+					cadence.String("/storage/exampleNFTCollection"),
+					cadence.String("A.01.ExampleNFT.Collection"),
+					cadence.NewArray([]cadence.Value{
+						cadence.NewStruct([]cadence.Value{
+							cadence.NewUInt64(42),
+							cadence.NewOptional(cadence.String("Example")),
+							cadence.NewOptional(cadence.String("https://example.com/42.png")),
+						}).WithType(nftType),
+					}),
+				}).WithType(collectionType),
+			}), nil)
+		})
+
+		result, err := nfts([]string{"0x01"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, "Address: 0x0000000000000001, /storage/exampleNFTCollection: [42]", result.Oneliner())
+		assert.Contains(t, result.String(), "https://example.com/42.png")
+	})
+
+	t.Run("Success transfer", func(t *testing.T) {
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			script := args.Get(2).(flowkit.Script)
+			assert.Equal(t, `"exampleNFTCollection"`, script.Args[0].String())
+			assert.Equal(t, "42", script.Args[1].String())
+			assert.Equal(t, "nil", script.Args[3].String())
+		}).Return(tests.NewTransaction(), tests.NewTransactionResult(nil), nil)
+
+		inArgs := []string{"/storage/exampleNFTCollection", "42", "0x01"}
+		result, err := nftsTransfer(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+	})
+
+	t.Run("Fail transfer invalid ID", func(t *testing.T) {
+		inArgs := []string{"exampleNFTCollection", "invalid", "0x01"}
+		_, err := nftsTransfer(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "invalid NFT ID invalid, must be a positive number")
+	})
+}
+
+func Test_Fund(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+	require.NoError(t, state.SaveDefault())
+	srv.SendTransaction.Return(tests.NewTransaction(), tests.NewTransactionResult(nil), nil)
+
+	balance, _ := cadence.NewUFix64("10000.0")
+	srv.ExecuteScript.Run(func(args mock.Arguments) {
+		script := args.Get(1).(flowkit.Script)
+		if strings.Contains(string(script.Code), "FungibleToken.Receiver") {
+			srv.ExecuteScript.Return(cadence.NewBool(true), nil)
+			return
+		}
+		srv.ExecuteScript.Return(cadence.NewOptional(balance), nil)
+	})
+
+	t.Run("Success emulator", func(t *testing.T) {
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			roles := args.Get(1).(transactions.AccountRoles)
+			assert.Equal(t, "emulator-account", roles.Proposer.Name)
+			script := args.Get(2).(flowkit.Script)
+			assert.Equal(t, "500.00000000", script.Args[0].String())
+			assert.Equal(t, "0x01cf0e2f2f715450", script.Args[1].String())
+		})
+
+		fundFlags.Amount = "500"
+		globalFlags := command.GlobalFlags{ConfigPaths: config.DefaultPaths()}
+		result, err := fund([]string{"0x01cf0e2f2f715450"}, globalFlags, util.NoLogger, rw, srv.Mock)
+		fundFlags.Amount = "1000.0"
+		require.NoError(t, err)
+		require.NotNil(t, result)
+	})
+
+	t.Run("Fail invalid address", func(t *testing.T) {
+		_, err := fund([]string{"0x01"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "unsupported address 0000000000000001, faucet can only work for valid Testnet addresses")
+	})
+
+	address := flow.HexToAddress("0x8e94eaa81771313a")
+	faucet := func(t *testing.T, status int, response string) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+			var req faucetRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, faucetRequest{Address: "0x8e94eaa81771313a", Token: "FLOW"}, req)
+
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(response))
+		}))
+		t.Cleanup(server.Close)
+
+		defaultURL := testnetFaucetAPIURL
+		testnetFaucetAPIURL = server.URL
+		t.Cleanup(func() { testnetFaucetAPIURL = defaultURL })
+	}
+
+	t.Run("Success headless", func(t *testing.T) {
+		faucet(t, http.StatusOK, `{"token":"FLOW","amount":"1000.0"}`)
+
+		err := fundTestnetHeadless(util.NoLogger, address)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Fail headless faucet error", func(t *testing.T) {
+		faucet(t, http.StatusBadRequest, `{"errors":["Invalid address"]}`)
+
+		err := fundTestnetHeadless(util.NoLogger, address)
+		assert.EqualError(t, err, `could not fund the account, faucet responded with status 400: {"errors":["Invalid address"]}`)
+	})
+}
+
+func Test_Staking(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	srv.SendTransaction.Return(tests.NewTransaction(), tests.NewTransactionResult(nil), nil)
+
+	emulatorAccount, err := state.Accounts().ByName("emulator-account")
+	require.NoError(t, err)
+	state.Accounts().AddOrUpdate(&accounts.Account{
+		Name:    "alice",
+		Address: flow.NewAddressGenerator(flow.Testnet).NextAddress(),
+		Key:     emulatorAccount.Key,
+	})
+	globalFlags := command.GlobalFlags{Yes: true}
+
+	t.Run("Success stake", func(t *testing.T) {
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			script := args.Get(2).(flowkit.Script)
+			assert.Contains(t, string(script.Code), "import FlowStakingCollection from 0x95e019a17d0e23d7")
+			assert.Equal(t, `"node"`, script.Args[0].String())
+			assert.Equal(t, "2", script.Args[1].String())
+			assert.Equal(t, "100.00000000", script.Args[2].String())
+		})
+
+		stakeFlags.Signer = "alice"
+		stakeFlags.Delegator = "2"
+		result, err := stakeCommand.RunS([]string{"node", "100"}, globalFlags, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+	})
+
+	t.Run("Success delegate", func(t *testing.T) {
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			script := args.Get(2).(flowkit.Script)
+			assert.Len(t, script.Args, 2)
+			assert.Equal(t, "50.00000000", script.Args[1].String())
+		})
+
+		delegateFlags.Signer = "alice"
+		result, err := delegate([]string{"node", "50"}, globalFlags, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+	})
+
+	t.Run("Fail register node missing machine keys", func(t *testing.T) {
+		registerNodeFlags = flagsRegisterNode{
+			Signer:            "alice",
+			Role:              "collection",
+			NetworkingAddress: "collection.example.com:3569",
+			NetworkingKey:     "0x01",
+			StakingKey:        "0x02",
+			MachineSigAlgo:    "ECDSA_P256",
+			MachineHashAlgo:   "SHA3_256",
+		}
+		_, err := registerNode([]string{"node", "250000"}, globalFlags, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "machine account keys must be provided for collection nodes")
+	})
+
+	t.Run("Fail missing signer", func(t *testing.T) {
+		withdrawRewardsFlags.Signer = ""
+		_, err := withdrawRewardsCommand.RunS([]string{"node", "10"}, globalFlags, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "the account owning the staking collection must be provided with the --signer flag")
+	})
+
+	t.Run("Fail emulator chain", func(t *testing.T) {
+		unstakeFlags.Signer = "emulator-account"
+		_, err := unstakeCommand.RunS([]string{"node", "10"}, globalFlags, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "emulator chain not supported")
+	})
+}
+
+func Test_Diff(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+
+	accountA := tests.NewAccountWithAddress("0x01")
+	accountA.Contracts = map[string][]byte{
+		"Foo": []byte("import FungibleToken from 0xee82856bf20e2aa6\npub contract Foo {}"),
+		"Bar": []byte("pub contract Bar {}"),
+	}
+	accountB := tests.NewAccountWithAddress("0x02")
+	accountB.Contracts = map[string][]byte{
+		"Foo": []byte("import FungibleToken from 0x9a0766d93b6608b7\npub contract Foo {}"),
+		"Bar": []byte("pub contract Bar { pub let x: Int }"),
+	}
+
+	srv.GetAccount.Run(func(args mock.Arguments) {
+		if args.Get(1).(flow.Address).String() == "0000000000000001" {
+			srv.GetAccount.Return(accountA, nil)
+			return
+		}
+		srv.GetAccount.Return(accountB, nil)
+	})
+
+	t.Run("Success accounts", func(t *testing.T) {
+		result, err := diff([]string{"0x01", "0x02@emulator"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		diffResult := result.(*accountDiffResult)
+		assert.Equal(t, []contractDiff{
+			{name: "Bar", status: contractChanged, diff: diffResult.contracts[0].diff},
+			{name: "Foo", status: contractEqual},
+		}, diffResult.contracts)
+		assert.NotEmpty(t, diffResult.keys)
+	})
+
+	t.Run("Success deployment", func(t *testing.T) {
+		require.NoError(t, state.ReaderWriter().WriteFile("Foo.cdc", []byte(`import "FungibleToken"
+pub contract Foo {}`), 0644))
+		state.Contracts().AddOrUpdate(config.Contract{Name: "Foo", Location: "Foo.cdc"})
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network:   "emulator",
+			Account:   "emulator-account",
+			Contracts: []config.ContractDeployment{{Name: "Foo"}},
+		})
+
+		result, err := diff([]string{"emulator-account"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		diffResult := result.(*accountDiffResult)
+		assert.Equal(t, "flow.json@emulator", diffResult.to.label)
+		assert.Equal(t, []contractDiff{
+			{name: "Bar", status: contractMissing},
+			{name: "Foo", status: contractEqual},
+		}, diffResult.contracts)
+	})
+
+	t.Run("Fail unknown network", func(t *testing.T) {
+		_, err := diff([]string{"0x01@unknown"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "network named unknown does not exist in configuration")
+	})
+}
+
+func Test_ValidateContractUpdate(t *testing.T) {
+	account := tests.NewAccountWithAddress("0x01")
+	account.Contracts = map[string][]byte{
+		"Foo": []byte(`
+pub contract Foo {
+    pub let x: Int
+
+    init() {
+        self.x = 1
+    }
+}`),
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		err := validateContractUpdate(account, []byte(`
+pub contract Foo {
+    pub let x: Int
+
+    pub fun hello(): Int {
+        return self.x
+    }
+
+    init() {
+        self.x = 1
+    }
+}`))
+		assert.NoError(t, err)
+	})
+
+	t.Run("Fail field type changed", func(t *testing.T) {
+		err := validateContractUpdate(account, []byte(`
+pub contract Foo {
+    pub let x: String
+
+    init() {
+        self.x = "1"
+    }
+}`))
+		assert.EqualError(t, err, `contract Foo can not be updated on account 0x0000000000000001:
+  - 3:15: mismatching field `+"`x`"+` in `+"`Foo`"+`: incompatible type annotations. expected `+"`Int`"+`, found `+"`String`")
+	})
+
+	t.Run("Imports contract", func(t *testing.T) {
+		address := flow.HexToAddress("0x01")
+		assert.True(t, importsContract([]byte("import Foo from 0x01\npub contract Bar {}"), "Foo", address))
+		assert.True(t, importsContract([]byte("import \"Foo\"\npub contract Bar {}"), "Foo", address))
+		assert.False(t, importsContract([]byte("import Foo from 0x02\npub contract Bar {}"), "Foo", address))
+	})
+}
+
+func Test_Fixture(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+	srv.SendTransaction.Return(tests.NewTransaction(), tests.NewTransactionResult(nil), nil)
+
+	exported := tests.NewAccountWithAddress("0x01")
+	exported.Keys[0].Revoked = true
+	exported.Contracts = map[string][]byte{
+		"Bar": []byte("import Foo from 0x01\npub contract Bar {}"),
+		"Foo": []byte("pub contract Foo {}"),
+	}
+	srv.GetAccount.Run(func(args mock.Arguments) {
+		srv.GetAccount.Return(exported, nil)
+	})
+
+	t.Run("Success export", func(t *testing.T) {
+		exportFlags.Out = "fixture.json"
+		result, err := export([]string{"0x01"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Nil(t, result)
+
+		data, err := rw.ReadFile("fixture.json")
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"address": "0x0000000000000001"`)
+		assert.Contains(t, string(data), `"Foo": "pub contract Foo {}"`)
+	})
+
+	t.Run("Success import", func(t *testing.T) {
+		privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, []byte(strings.Repeat("s", crypto.MinSeedLength)))
+		require.NoError(t, err)
+		srv.Mock.On("GenerateKey", mock.Anything, mock.Anything, mock.Anything).Return(privateKey, nil)
+
+		// the created account assigns new indexes to the keys in reverse order
+		srv.CreateAccount.Return(func(
+			_ context.Context,
+			_ *accounts.Account,
+			keys []accounts.PublicKey,
+		) (*flow.Account, flow.Identifier, error) {
+			assert.Len(t, keys, len(exported.Keys)+1)
+			created := tests.NewAccountWithAddress("0x02")
+			created.Keys = nil
+			for i, key := range keys {
+				created.Keys = append(created.Keys, &flow.AccountKey{
+					Index:     len(keys) - 1 - i,
+					PublicKey: key.Public,
+					Weight:    key.Weight,
+				})
+			}
+			return created, flow.EmptyID, nil
+		})
+
+		deployed := make([]string, 0)
+		srv.AddContract.Run(func(args mock.Arguments) {
+			deployed = append(deployed, args.Get(2).(flowkit.Script).Location)
+		})
+
+		revoked := make([]string, 0)
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			script := args.Get(2).(flowkit.Script)
+			if strings.Contains(string(script.Code), "keys.revoke") {
+				revoked = append(revoked, script.Args[0].String())
+			}
+		})
+
+		result, err := importFixture([]string{"fixture.json"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, []string{"Foo", "Bar"}, deployed)
+		assert.Equal(t, []string{fmt.Sprintf("%d", len(exported.Keys))}, revoked)
+
+		account, err := state.Accounts().ByName("fixture")
+		require.NoError(t, err)
+		assert.Equal(t, 0, account.Key.Index())
+		assert.Equal(t, config.KeyTypeFile, account.Key.Type())
+
+		saved, err := rw.ReadFile("fixture.pkey")
+		require.NoError(t, err)
+		assert.Equal(t, privateKey.String(), string(saved))
+	})
+
+	t.Run("Fail import existing account name", func(t *testing.T) {
+		importFlags.Name = "emulator-account"
+		defer func() { importFlags.Name = "fixture" }()
+
+		_, err := importFixture([]string{"fixture.json"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "account with name emulator-account already exists in the configuration")
+	})
+
+	t.Run("Rewrite imports", func(t *testing.T) {
+		testnet, err := systemContractsForNetwork(config.TestnetNetwork, flow.EmptyAddress)
+		require.NoError(t, err)
+		emulator, err := systemContractsForNetwork(config.EmulatorNetwork, flow.EmptyAddress)
+		require.NoError(t, err)
+
+		code := rewriteFixtureImports(
+			"import FungibleToken from 0x9a0766d93b6608b7\nimport Foo from 0x01\nimport Other from 0x02",
+			flow.HexToAddress("0x01"),
+			flow.HexToAddress("0x03"),
+			testnet,
+			emulator,
+		)
+		assert.Equal(t, "import FungibleToken from 0xee82856bf20e2aa6\nimport Foo from 0x0000000000000003\nimport Other from 0x02", code)
+	})
+}
+
 func Test_Result(t *testing.T) {
 	pkey, _ := crypto.DecodePublicKeyHex(crypto.ECDSA_P256, "a60b9c10a39070806d37d8f0e6be081e7af2d18cd92ee1bd850d10c994d61d538d2693eebe8faa94fea59ee579ea65a70ed897b05126e508e74f55b8669eec6b")
 	account := &flow.Account{
@@ -313,3 +1014,86 @@ Contracts (hidden, use --include contracts)`, "\n"), result.String())
 	}, result.JSON())
 
 }
+
+func Test_History(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	address := flow.HexToAddress("0x01cf0e2f2f715450")
+	other := flow.HexToAddress("0x179b6b1cb6755e31")
+
+	// the fixtures are created upfront since the blocks are scanned concurrently
+	signed := flow.NewTransaction().SetScript([]byte("signed")).SetPayer(address).SetProposalKey(other, 0, 0)
+	unrelated := flow.NewTransaction().SetScript([]byte("unrelated")).SetPayer(other).AddAuthorizer(other)
+	received := flow.NewTransaction().SetScript([]byte("received")).SetPayer(other).AddAuthorizer(other)
+
+	deposited := tests.NewEvent(
+		0,
+		"A.0ae53cb6e3f42a79.FlowToken.TokensDeposited",
+		[]cadence.Field{{Identifier: "amount", Type: cadence.UFix64Type{}}, {Identifier: "to", Type: cadence.AddressType{}}},
+		[]cadence.Value{cadence.UFix64(100), cadence.NewOptional(cadence.NewAddress(address))},
+	)
+
+	failed := tests.NewTransactionResult(nil)
+	failed.Error = fmt.Errorf("execution reverted")
+	results := []*flow.TransactionResult{failed, tests.NewTransactionResult(nil), tests.NewTransactionResult([]flow.Event{*deposited})}
+
+	srv.GetBlock.Return(func(_ context.Context, query flowkit.BlockQuery) (*flow.Block, error) {
+		block := &flow.Block{}
+		block.Height = query.Height
+		if query.Latest {
+			block.Height = 12
+		}
+		return block, nil
+	})
+
+	srv.GetTransactionsByBlockID.Return(func(context.Context, flow.Identifier) ([]*flow.Transaction, []*flow.TransactionResult, error) {
+		return []*flow.Transaction{signed, unrelated, received}, results, nil
+	}, nil, nil)
+
+	t.Run("Success", func(t *testing.T) {
+		historyFlags.Start = 0
+		historyFlags.End = 0
+		historyFlags.Last = 2
+		historyFlags.Workers = 2
+
+		result, err := history([]string{address.String()}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		r := result.(*historyResult)
+		assert.Equal(t, uint64(11), r.start)
+		assert.Equal(t, uint64(12), r.end)
+		require.Len(t, r.entries, 4)
+
+		for i, entry := range r.entries {
+			assert.Equal(t, uint64(11+i/2), entry.height)
+		}
+		assert.Equal(t, []string{"payer"}, r.entries[0].roles)
+		assert.Equal(t, "FAILED", historyStatus(r.entries[0]))
+		assert.Empty(t, r.entries[1].roles)
+		assert.Equal(t, []string{"A.0ae53cb6e3f42a79.FlowToken.TokensDeposited"}, r.entries[1].events)
+		assert.Contains(t, result.String(), "Roles\t payer")
+	})
+
+	historyStartChanged, historyEndChanged = true, true
+	defer func() { historyStartChanged, historyEndChanged = false, false }()
+
+	t.Run("Success range from genesis", func(t *testing.T) {
+		historyFlags.Start = 0
+		historyFlags.End = 1
+
+		result, err := history([]string{address.String()}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		r := result.(*historyResult)
+		assert.Equal(t, uint64(0), r.start)
+		assert.Equal(t, uint64(1), r.end)
+		assert.Len(t, r.entries, 4)
+	})
+
+	t.Run("Fail invalid range", func(t *testing.T) {
+		historyFlags.Start = 20
+		historyFlags.End = 10
+
+		_, err := history([]string{address.String()}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "start height 20 must not be greater than end height 10")
+	})
+}
@@ -36,10 +36,11 @@ import (
 )
 
 type deployContractFlags struct {
-	ArgsJSON string   `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
-	Signer   string   `default:"emulator-account" flag:"signer" info:"Account name from configuration used to sign the transaction"`
-	Include  []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: contracts."`
-	ShowDiff bool     `default:"false" flag:"show-diff" info:"Shows diff between existing and new contracts on update"`
+	ArgsJSON       string   `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
+	Signer         string   `default:"emulator-account" flag:"signer" info:"Account name from configuration used to sign the transaction"`
+	Include        []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: contracts."`
+	ShowDiff       bool     `default:"false" flag:"show-diff" info:"Shows diff between existing and new contracts on update"`
+	SkipValidation bool     `default:"false" flag:"skip-validation" info:"Skip the local contract updatability validation on update"`
 }
 
 var addContractFlags = deployContractFlags{}
@@ -86,6 +87,15 @@ func deployContract(update bool, flags *deployContractFlags) command.RunWithStat
 			return nil, fmt.Errorf("error parsing transaction arguments: %w", err)
 		}
 
+		if update && !flags.SkipValidation {
+			logger.StartProgress("Validating contract update...")
+			err = checkContractUpdate(flow, state, to.Address, code, filename)
+			logger.StopProgress()
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		deployFunc := flowkit.UpdateExistingContract(update)
 		if updateContractFlags.ShowDiff {
 			deployFunc = prompt.ShowContractDiffPrompt(logger)
@@ -65,6 +65,8 @@ func removeContract(
 		return nil, err
 	}
 
+	warnContractImporters(flow, state, logger, contractName, from.Address)
+
 	id, err := flow.RemoveContract(context.Background(), from, contractName)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,234 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser"
+	"github.com/onflow/cadence/runtime/stdlib"
+	flowsdk "github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/output"
+	"github.com/onflow/flowkit/project"
+)
+
+// checkContractUpdate resolves the contract imports the same way the deployment does,
+// fetches the currently deployed code and validates the update.
+func checkContractUpdate(
+	flow flowkit.Services,
+	state *flowkit.State,
+	address flowsdk.Address,
+	code []byte,
+	location string,
+) error {
+	program, err := project.NewProgram(code, nil, location)
+	if err != nil {
+		return err
+	}
+
+	if program.HasImports() {
+		contracts, err := state.DeploymentContractsByNetwork(flow.Network())
+		if err != nil {
+			return err
+		}
+
+		importReplacer := project.NewImportReplacer(contracts, state.AliasesForNetwork(flow.Network()))
+		program, err = importReplacer.Replace(program)
+		if err != nil {
+			return err
+		}
+	}
+
+	account, err := flow.GetAccount(context.Background(), address)
+	if err != nil {
+		return err
+	}
+
+	return validateContractUpdate(account, program.Code())
+}
+
+// contractName returns the name of the contract or contract interface declared in the program.
+func contractName(program *ast.Program) (string, error) {
+	if contract := program.SoleContractDeclaration(); contract != nil {
+		return contract.Identifier.Identifier, nil
+	}
+	if contractInterface := program.SoleContractInterfaceDeclaration(); contractInterface != nil {
+		return contractInterface.Identifier.Identifier, nil
+	}
+
+	return "", fmt.Errorf("the code must declare exactly one contract or contract interface")
+}
+
+// accountContractNames provides the names of the contracts deployed on the account to the update validator.
+type accountContractNames struct {
+	account *flowsdk.Account
+}
+
+var _ stdlib.AccountContractNamesProvider = accountContractNames{}
+
+func (a accountContractNames) GetAccountContractNames(common.Address) ([]string, error) {
+	names := make([]string, 0, len(a.account.Contracts))
+	for name := range a.account.Contracts {
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+// validateContractUpdate checks the new contract code against the code deployed on the account
+// using the Cadence contract updatability rules, so invalid updates are reported before the transaction is signed.
+//
+// Nothing is validated if the contract is not yet deployed on the account.
+func validateContractUpdate(account *flowsdk.Account, newCode []byte) error {
+	newProgram, err := parser.ParseProgram(nil, newCode, parser.Config{})
+	if err != nil {
+		return fmt.Errorf("failed to parse the new contract code: %w", err)
+	}
+
+	name, err := contractName(newProgram)
+	if err != nil {
+		return err
+	}
+
+	oldCode, ok := account.Contracts[name]
+	if !ok {
+		return nil
+	}
+
+	oldProgram, err := parser.ParseProgram(nil, oldCode, parser.Config{})
+	if err != nil {
+		return fmt.Errorf("failed to parse the deployed contract code: %w", err)
+	}
+
+	location := common.NewAddressLocation(nil, common.Address(account.Address), name)
+	validator := stdlib.NewContractUpdateValidator(location, name, accountContractNames{account}, oldProgram, newProgram)
+
+	err = validator.Validate()
+	if err == nil {
+		return nil
+	}
+
+	var updateErr *stdlib.ContractUpdateError
+	if !errors.As(err, &updateErr) {
+		return err
+	}
+
+	return fmt.Errorf(
+		"contract %s can not be updated on account 0x%s:\n%s",
+		name,
+		account.Address,
+		formatUpdateErrors(updateErr.Errors),
+	)
+}
+
+// formatUpdateErrors lists the update validation errors with their source positions in the new code.
+func formatUpdateErrors(updateErrors []error) string {
+	lines := make([]string, 0, len(updateErrors))
+	for _, err := range updateErrors {
+		message := err.Error()
+		if secondary, ok := err.(interface{ SecondaryError() string }); ok {
+			message = fmt.Sprintf("%s: %s", message, secondary.SecondaryError())
+		}
+		if positioned, ok := err.(ast.HasPosition); ok {
+			position := positioned.StartPosition()
+			message = fmt.Sprintf("%d:%d: %s", position.Line, position.Column, message)
+		}
+		lines = append(lines, fmt.Sprintf("  - %s", message))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// importsContract returns true if the contract code imports the contract with the provided name from the address.
+func importsContract(code []byte, name string, address flowsdk.Address) bool {
+	program, err := parser.ParseProgram(nil, code, parser.Config{})
+	if err != nil {
+		return false
+	}
+
+	for _, declaration := range program.ImportDeclarations() {
+		switch location := declaration.Location.(type) {
+		case common.AddressLocation:
+			if location.Address != common.Address(address) {
+				continue
+			}
+			for _, identifier := range declaration.Identifiers {
+				if identifier.Identifier == name {
+					return true
+				}
+			}
+		case common.StringLocation:
+			if string(location) == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// warnContractImporters warns about the contracts deployed on the accounts in the configuration
+// which import the contract being removed, since they will fail once the contract is removed.
+func warnContractImporters(
+	flow flowkit.Services,
+	state *flowkit.State,
+	logger output.Logger,
+	name string,
+	address flowsdk.Address,
+) {
+	addresses := []flowsdk.Address{address}
+	for _, account := range *state.AccountsForNetwork(flow.Network()) {
+		if account.Address != address {
+			addresses = append(addresses, account.Address)
+		}
+	}
+
+	importers := make([]string, 0)
+	for _, accountAddress := range addresses {
+		account, err := flow.GetAccount(context.Background(), accountAddress)
+		if err != nil {
+			continue
+		}
+
+		for contract, code := range account.Contracts {
+			if accountAddress == address && contract == name {
+				continue
+			}
+			if importsContract(code, name, address) {
+				importers = append(importers, fmt.Sprintf("%s on account 0x%s", contract, accountAddress))
+			}
+		}
+	}
+
+	if len(importers) > 0 {
+		logger.Info(fmt.Sprintf(
+			"%s Contract %s is imported by the following deployed contracts which will stop working:\n  - %s",
+			output.WarningEmoji(),
+			name,
+			strings.Join(importers, "\n  - "),
+		))
+	}
+}
@@ -0,0 +1,234 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"gopkg.in/yaml.v3"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/accounts"
+	"github.com/onflow/flowkit/output"
+
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// accountSpec describes the account to create, it is loaded from the spec file provided to the create command.
+//
+// Example spec for an account with three keys where any two keys are required to sign:
+//
+//	name: operations
+//	keys:
+//	  - name: alice
+//	    publicKey: 0x5d2f...aa1
+//	    weight: 500
+//	  - name: bob
+//	    publicKey: 0x9f1e...0b2
+//	    weight: 500
+//	  - name: local
+//	    generate: true
+//	    weight: 500
+type accountSpec struct {
+	Name string           `yaml:"name"`
+	Keys []accountSpecKey `yaml:"keys"`
+}
+
+type accountSpecKey struct {
+	Name      string `yaml:"name"`
+	PublicKey string `yaml:"publicKey"`
+	SigAlgo   string `yaml:"sigAlgo"`
+	HashAlgo  string `yaml:"hashAlgo"`
+	Weight    int    `yaml:"weight"`
+	Generate  bool   `yaml:"generate"`
+}
+
+// parseAccountSpec parses and validates the account spec file content.
+func parseAccountSpec(data []byte) (*accountSpec, error) {
+	var spec accountSpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse account spec: %w", err)
+	}
+
+	if spec.Name == "" {
+		return nil, fmt.Errorf("account spec must contain the account name")
+	}
+	if len(spec.Keys) == 0 {
+		return nil, fmt.Errorf("account spec must contain at least one key")
+	}
+
+	totalWeight := 0
+	generated := 0
+	for i, key := range spec.Keys {
+		if key.Name == "" {
+			return nil, fmt.Errorf("key %d in the account spec must have a name", i)
+		}
+		if key.Generate == (key.PublicKey != "") {
+			return nil, fmt.Errorf("key %s must either provide a public key or be generated", key.Name)
+		}
+		if key.Weight < 0 || key.Weight > flowsdk.AccountKeyWeightThreshold {
+			return nil, fmt.Errorf("invalid weight %d for key %s, must be between 0 and %d", key.Weight, key.Name, flowsdk.AccountKeyWeightThreshold)
+		}
+		if key.SigAlgo == "" {
+			spec.Keys[i].SigAlgo = defaultSignAlgo.String()
+		}
+		if key.HashAlgo == "" {
+			spec.Keys[i].HashAlgo = defaultHashAlgo.String()
+		}
+		if key.Generate {
+			generated++
+		}
+		totalWeight += key.Weight
+	}
+
+	if generated > 1 {
+		return nil, fmt.Errorf("account spec can only contain one key to generate, found %d", generated)
+	}
+	if totalWeight < flowsdk.AccountKeyWeightThreshold {
+		return nil, fmt.Errorf(
+			"total key weight %d is below the threshold of %d required to sign transactions",
+			totalWeight,
+			flowsdk.AccountKeyWeightThreshold,
+		)
+	}
+
+	return &spec, nil
+}
+
+// createFromSpec creates the account described in the spec file and adds it to the configuration.
+//
+// The generated key private key is saved into a separate key file which is used by the account in the configuration,
+// if the spec does not contain a key to generate the account can not be added to the configuration.
+func createFromSpec(
+	state *flowkit.State,
+	flow flowkit.Services,
+	logger output.Logger,
+) (*accountResult, error) {
+	data, err := state.ReaderWriter().ReadFile(createFlags.FromSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read account spec: %w", err)
+	}
+
+	spec, err := parseAccountSpec(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := state.Accounts().ByName(spec.Name); err == nil {
+		return nil, fmt.Errorf("account with name %s already exists in the configuration", spec.Name)
+	}
+
+	signer, err := state.Accounts().ByName(createFlags.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	var privateKey crypto.PrivateKey
+	generatedIndex := -1
+	keys := make([]accounts.PublicKey, 0, len(spec.Keys))
+	for i, key := range spec.Keys {
+		sigAlgos, err := parseSignatureAlgorithms([]string{key.SigAlgo})
+		if err != nil {
+			return nil, err
+		}
+
+		hashAlgos, err := parseHashingAlgorithms([]string{key.HashAlgo})
+		if err != nil {
+			return nil, err
+		}
+
+		var publicKey crypto.PublicKey
+		if key.Generate {
+			privateKey, err = flow.GenerateKey(context.Background(), sigAlgos[0], "")
+			if err != nil {
+				return nil, err
+			}
+			publicKey = privateKey.PublicKey()
+			generatedIndex = i
+		} else {
+			pubKeys, err := parsePublicKeys([]string{key.PublicKey}, sigAlgos)
+			if err != nil {
+				return nil, err
+			}
+			publicKey = pubKeys[0]
+		}
+
+		keys = append(keys, accounts.PublicKey{
+			Public:   publicKey,
+			Weight:   key.Weight,
+			SigAlgo:  sigAlgos[0],
+			HashAlgo: hashAlgos[0],
+		})
+	}
+
+	account, _, err := flow.CreateAccount(context.Background(), signer, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	if privateKey == nil {
+		logger.Info(fmt.Sprintf(
+			"Account %s was not added to the configuration because the spec does not contain a key to generate.",
+			spec.Name,
+		))
+		return &accountResult{Account: account, include: createFlags.Include}, nil
+	}
+
+	privateFile := accounts.PrivateKeyFile(spec.Name, "")
+	err = util.AddToGitIgnore(privateFile, state.ReaderWriter())
+	if err != nil {
+		return nil, err
+	}
+
+	err = state.ReaderWriter().WriteFile(privateFile, []byte(privateKey.String()), os.FileMode(0644))
+	if err != nil {
+		return nil, fmt.Errorf("failed saving private key: %w", err)
+	}
+
+	state.Accounts().AddOrUpdate(&accounts.Account{
+		Name:    spec.Name,
+		Address: account.Address,
+		Key: accounts.NewFileKey(
+			privateFile,
+			generatedIndex,
+			keys[generatedIndex].SigAlgo,
+			keys[generatedIndex].HashAlgo,
+			state.ReaderWriter(),
+		),
+	})
+
+	err = state.SaveDefault()
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(fmt.Sprintf(
+		"Account %s added to the configuration using key %s with index %d saved to %s.",
+		spec.Name,
+		spec.Keys[generatedIndex].Name,
+		generatedIndex,
+		privateFile,
+	))
+
+	return &accountResult{Account: account, include: createFlags.Include}, nil
+}
@@ -41,15 +41,18 @@ type flagsCreate struct {
 	SigAlgo  []string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm used to generate the keys"`
 	HashAlgo []string `default:"SHA3_256" flag:"hash-algo" info:"Hash used for the digest"`
 	Include  []string `default:"" flag:"include" info:"Fields to include in the output"`
+	FromSpec string   `default:"" flag:"from-spec" info:"Path to a YAML file describing the account keys, weights and the key to generate"`
 }
 
 var createFlags = flagsCreate{}
 
 var createCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "create",
-		Short:   "Create a new account on network",
-		Example: `flow accounts create --key d651f1931a2...8745`,
+		Use:   "create",
+		Short: "Create a new account on network",
+		Example: `flow accounts create --key d651f1931a2...8745
+
+flow accounts create --from-spec account.yaml`,
 	},
 	Flags: &createFlags,
 	RunS:  create,
@@ -58,10 +61,14 @@ var createCommand = &command.Command{
 func create(
 	_ []string,
 	_ command.GlobalFlags,
-	_ output.Logger,
+	logger output.Logger,
 	flow flowkit.Services,
 	state *flowkit.State,
 ) (command.Result, error) {
+	if createFlags.FromSpec != "" {
+		return createFromSpec(state, flow, logger)
+	}
+
 	if len(createFlags.Keys) == 0 { // if user doesn't provide any flags go into interactive mode
 		return createInteractive(state)
 	} else {
@@ -0,0 +1,374 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/config"
+	"github.com/onflow/flowkit/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/prompt"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsDiff struct{}
+
+var diffFlags = flagsDiff{}
+
+var diffCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "diff <address|account name>[@network] [<address|account name>@network]",
+		Short: "Compare contracts, keys and balances of two accounts or of an account and the local deployment",
+		Example: `flow accounts diff 0x01cf0e2f2f715450@testnet 0x179b6b1cb6755e31@mainnet
+
+flow accounts diff alice@testnet`,
+		Args: cobra.RangeArgs(1, 2),
+	},
+	Flags: &diffFlags,
+	RunS:  diff,
+}
+
+// accountState is the account data being compared, keys and balance are not known for the local deployment.
+type accountState struct {
+	label     string
+	balance   *uint64
+	keys      []*flowsdk.AccountKey
+	contracts map[string][]byte
+}
+
+func diff(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	logger.StartProgress("Loading accounts...")
+	defer logger.StopProgress()
+
+	from, err := loadAccountState(flow, state, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var to *accountState
+	if len(args) == 2 {
+		to, err = loadAccountState(flow, state, args[1])
+	} else {
+		to, err = loadDeploymentState(flow, state, args[0])
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return newAccountDiffResult(from, to), nil
+}
+
+// parseAccountReference splits the reference into the address or account name and the network,
+// the network defaults to the network the command is run on.
+func parseAccountReference(
+	flow flowkit.Services,
+	state *flowkit.State,
+	reference string,
+) (flowsdk.Address, *config.Network, error) {
+	value, networkName, found := strings.Cut(reference, "@")
+	if !found {
+		networkName = flow.Network().Name
+	}
+
+	network, err := state.Networks().ByName(networkName)
+	if err != nil {
+		return flowsdk.EmptyAddress, nil, err
+	}
+
+	address, err := resolveAddress(state, value)
+	if err != nil {
+		return flowsdk.EmptyAddress, nil, err
+	}
+
+	return address, network, nil
+}
+
+func loadAccountState(flow flowkit.Services, state *flowkit.State, reference string) (*accountState, error) {
+	address, network, err := parseAccountReference(flow, state, reference)
+	if err != nil {
+		return nil, err
+	}
+
+	services, err := util.ServicesForNetwork(flow, state, network)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := services.GetAccount(context.Background(), address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountState{
+		label:     fmt.Sprintf("0x%s@%s", address, network.Name),
+		balance:   &account.Balance,
+		keys:      account.Keys,
+		contracts: account.Contracts,
+	}, nil
+}
+
+// loadDeploymentState returns the contracts deployed to the account on the network in the configuration.
+func loadDeploymentState(flow flowkit.Services, state *flowkit.State, reference string) (*accountState, error) {
+	address, network, err := parseAccountReference(flow, state, reference)
+	if err != nil {
+		return nil, err
+	}
+
+	deployments, err := state.DeploymentContractsByNetwork(*network)
+	if err != nil {
+		return nil, err
+	}
+
+	contracts := make(map[string][]byte)
+	for _, contract := range deployments {
+		if contract.AccountAddress == address {
+			contracts[contract.Name] = contract.Code()
+		}
+	}
+
+	return &accountState{
+		label:     fmt.Sprintf("flow.json@%s", network.Name),
+		contracts: contracts,
+	}, nil
+}
+
+var (
+	addressImportRegex = regexp.MustCompile(`(?m)^(\s*import\s+.+?)\s+from\s+(?:0x[0-9a-fA-F]+|"[^"]*")`)
+	stringImportRegex  = regexp.MustCompile(`(?m)^(\s*import\s+)"([^"]+)"`)
+)
+
+// normalizeImports replaces imports from addresses and files with imports by contract name,
+// so the same contract deployed on different networks can be compared.
+func normalizeImports(code []byte) string {
+	normalized := addressImportRegex.ReplaceAllString(string(code), "$1")
+	normalized = stringImportRegex.ReplaceAllString(normalized, "$1$2")
+	return strings.TrimSpace(normalized)
+}
+
+const (
+	contractEqual   = "equal"
+	contractChanged = "changed"
+	contractMissing = "missing"
+	contractAdded   = "added"
+)
+
+type contractDiff struct {
+	name   string
+	status string
+	diff   string
+}
+
+type accountDiffResult struct {
+	from      *accountState
+	to        *accountState
+	contracts []contractDiff
+	keys      []string
+}
+
+func newAccountDiffResult(from *accountState, to *accountState) *accountDiffResult {
+	names := make(map[string]bool)
+	for name := range from.contracts {
+		names[name] = true
+	}
+	for name := range to.contracts {
+		names[name] = true
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	contracts := make([]contractDiff, 0, len(sorted))
+	for _, name := range sorted {
+		fromCode, inFrom := from.contracts[name]
+		toCode, inTo := to.contracts[name]
+
+		switch {
+		case !inTo:
+			contracts = append(contracts, contractDiff{name: name, status: contractMissing})
+		case !inFrom:
+			contracts = append(contracts, contractDiff{name: name, status: contractAdded})
+		case normalizeImports(fromCode) == normalizeImports(toCode):
+			contracts = append(contracts, contractDiff{name: name, status: contractEqual})
+		default:
+			contracts = append(contracts, contractDiff{
+				name:   name,
+				status: contractChanged,
+				diff:   prompt.ContractDiff([]byte(normalizeImports(fromCode)), []byte(normalizeImports(toCode))),
+			})
+		}
+	}
+
+	return &accountDiffResult{
+		from:      from,
+		to:        to,
+		contracts: contracts,
+		keys:      diffKeys(from, to),
+	}
+}
+
+// diffKeys describes the differences between the keys with the same index, keys are not compared for the local deployment.
+func diffKeys(from *accountState, to *accountState) []string {
+	if from.keys == nil || to.keys == nil {
+		return nil
+	}
+
+	toKeys := make(map[int]*flowsdk.AccountKey)
+	for _, key := range to.keys {
+		toKeys[key.Index] = key
+	}
+
+	differences := make([]string, 0)
+	for _, key := range from.keys {
+		other, ok := toKeys[key.Index]
+		delete(toKeys, key.Index)
+		if !ok {
+			differences = append(differences, fmt.Sprintf("key %d only exists on %s", key.Index, from.label))
+			continue
+		}
+
+		if !key.PublicKey.Equals(other.PublicKey) {
+			differences = append(differences, fmt.Sprintf("key %d public keys differ", key.Index))
+		}
+		if key.Weight != other.Weight {
+			differences = append(differences, fmt.Sprintf("key %d weights differ: %d and %d", key.Index, key.Weight, other.Weight))
+		}
+		if key.SigAlgo != other.SigAlgo || key.HashAlgo != other.HashAlgo {
+			differences = append(differences, fmt.Sprintf(
+				"key %d algorithms differ: %s/%s and %s/%s",
+				key.Index, key.SigAlgo, key.HashAlgo, other.SigAlgo, other.HashAlgo,
+			))
+		}
+		if key.Revoked != other.Revoked {
+			differences = append(differences, fmt.Sprintf("key %d revoked status differs: %t and %t", key.Index, key.Revoked, other.Revoked))
+		}
+	}
+
+	indexes := make([]int, 0, len(toKeys))
+	for index := range toKeys {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	for _, index := range indexes {
+		differences = append(differences, fmt.Sprintf("key %d only exists on %s", index, to.label))
+	}
+
+	return differences
+}
+
+// equal returns true if there are no differences between the compared accounts contracts and keys.
+func (r *accountDiffResult) equal() bool {
+	for _, contract := range r.contracts {
+		if contract.status != contractEqual {
+			return false
+		}
+	}
+
+	return len(r.keys) == 0
+}
+
+func (r *accountDiffResult) JSON() any {
+	result := make(map[string]any)
+	result["from"] = r.from.label
+	result["to"] = r.to.label
+	result["equal"] = r.equal()
+
+	contracts := make(map[string]string)
+	for _, contract := range r.contracts {
+		contracts[contract.name] = contract.status
+	}
+	result["contracts"] = contracts
+
+	if r.from.balance != nil && r.to.balance != nil {
+		result["balances"] = map[string]string{
+			r.from.label: cadence.UFix64(*r.from.balance).String(),
+			r.to.label:   cadence.UFix64(*r.to.balance).String(),
+		}
+		result["keys"] = r.keys
+	}
+
+	return result
+}
+
+func (r *accountDiffResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Comparing %s with %s\n\n", r.from.label, r.to.label)
+
+	if r.from.balance != nil && r.to.balance != nil {
+		_, _ = fmt.Fprintf(writer, "Balance\t %s\t %s\n", cadence.UFix64(*r.from.balance), cadence.UFix64(*r.to.balance))
+		_, _ = fmt.Fprintf(writer, "Keys\t %d\t %d\n", len(r.from.keys), len(r.to.keys))
+		for _, key := range r.keys {
+			_, _ = fmt.Fprintf(writer, "\t %s\n", key)
+		}
+	}
+
+	_, _ = fmt.Fprintf(writer, "Contracts\t %d\t %d\n", len(r.from.contracts), len(r.to.contracts))
+	for _, contract := range r.contracts {
+		status := contract.status
+		switch contract.status {
+		case contractMissing:
+			status = fmt.Sprintf("missing on %s", r.to.label)
+		case contractAdded:
+			status = fmt.Sprintf("missing on %s", r.from.label)
+		}
+		_, _ = fmt.Fprintf(writer, "\t %s\t %s\n", contract.name, status)
+	}
+	_ = writer.Flush()
+
+	for _, contract := range r.contracts {
+		if contract.status == contractChanged {
+			_, _ = fmt.Fprintf(&b, "\nContract %s\n%s\n", contract.name, contract.diff)
+		}
+	}
+
+	return b.String()
+}
+
+func (r *accountDiffResult) Oneliner() string {
+	changed := make([]string, 0)
+	for _, contract := range r.contracts {
+		if contract.status != contractEqual {
+			changed = append(changed, fmt.Sprintf("%s (%s)", contract.name, contract.status))
+		}
+	}
+
+	return fmt.Sprintf("From: %s, To: %s, Equal: %t, Contracts: %s", r.from.label, r.to.label, r.equal(), strings.Join(changed, ", "))
+}
@@ -0,0 +1,188 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsExport struct {
+	Out     string   `default:"fixture.json" flag:"out" info:"File the account fixture is written to"`
+	Storage []string `default:"" flag:"storage" info:"Storage path identifiers of the struct values to include in the fixture"`
+}
+
+var exportFlags = flagsExport{}
+
+var exportCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "export <address>",
+		Short:   "Export account contracts, keys, balance and selected storage to a fixture file",
+		Example: "flow accounts export 0x01cf0e2f2f715450 --out fixture.json --storage exampleConfig --network testnet",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &exportFlags,
+	Run:   export,
+}
+
+// storageValuesScript copies the struct values stored at the provided storage paths, resources can not be copied.
+const storageValuesScript = `
+pub fun main(address: Address, identifiers: [String]): {String: AnyStruct} {
+    let account = getAuthAccount(address)
+    let values: {String: AnyStruct} = {}
+
+    for identifier in identifiers {
+        let path = StoragePath(identifier: identifier) ?? panic("invalid storage path")
+        if let value = account.copy<AnyStruct>(from: path) {
+            values[identifier] = value
+        }
+    }
+
+    return values
+}
+`
+
+func export(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	address := flowsdk.HexToAddress(args[0])
+
+	logger.StartProgress(fmt.Sprintf("Exporting account 0x%s...", address))
+	defer logger.StopProgress()
+
+	account, err := flow.GetAccount(context.Background(), address)
+	if err != nil {
+		return nil, err
+	}
+
+	fixture := &accountFixture{
+		Address:   fmt.Sprintf("0x%s", address),
+		Network:   flow.Network().Name,
+		Balance:   cadence.UFix64(account.Balance).String(),
+		Keys:      make([]fixtureKey, 0, len(account.Keys)),
+		Contracts: make(map[string]string),
+	}
+
+	for _, key := range account.Keys {
+		fixture.Keys = append(fixture.Keys, fixtureKey{
+			Index:     key.Index,
+			PublicKey: strings.TrimPrefix(key.PublicKey.String(), "0x"),
+			SigAlgo:   key.SigAlgo.String(),
+			HashAlgo:  key.HashAlgo.String(),
+			Weight:    key.Weight,
+			Revoked:   key.Revoked,
+		})
+	}
+
+	for name, code := range account.Contracts {
+		fixture.Contracts[name] = string(code)
+	}
+
+	fixture.Storage, err = exportStorage(flow, address, exportFlags.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(fixture, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+
+	err = readerWriter.WriteFile(exportFlags.Out, data, os.FileMode(0644))
+	if err != nil {
+		return nil, fmt.Errorf("failed to write the fixture: %w", err)
+	}
+
+	logger.StopProgress()
+	logger.Info(fmt.Sprintf(
+		"%s Account 0x%s exported to %s with %d contracts and %d keys.",
+		output.SuccessEmoji(),
+		address,
+		exportFlags.Out,
+		len(fixture.Contracts),
+		len(fixture.Keys),
+	))
+
+	return nil, nil
+}
+
+// exportStorage returns the JSON-Cadence encoded struct values stored at the provided storage paths.
+func exportStorage(flow flowkit.Services, address flowsdk.Address, identifiers []string) (map[string]json.RawMessage, error) {
+	paths := make([]cadence.Value, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		if identifier != "" {
+			paths = append(paths, cadence.String(strings.TrimPrefix(identifier, "/storage/")))
+		}
+	}
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	value, err := flow.ExecuteScript(
+		context.Background(),
+		flowkit.Script{
+			Code: []byte(storageValuesScript),
+			Args: []cadence.Value{cadence.NewAddress(address), cadence.NewArray(paths)},
+		},
+		flowkit.LatestScriptQuery,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the account storage: %w", err)
+	}
+
+	dictionary, ok := value.(cadence.Dictionary)
+	if !ok {
+		return nil, fmt.Errorf("storage values must be a cadence dictionary")
+	}
+
+	storage := make(map[string]json.RawMessage)
+	for _, pair := range dictionary.Pairs {
+		encoded, err := jsoncdc.Encode(pair.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode the storage value at %s: %w", pair.Key, err)
+		}
+		storage[optionalString(pair.Key)] = encoded
+	}
+
+	for _, path := range paths {
+		identifier := string(path.(cadence.String))
+		if _, ok := storage[identifier]; !ok {
+			return nil, fmt.Errorf("no struct value is stored at /storage/%s", identifier)
+		}
+	}
+
+	return storage, nil
+}
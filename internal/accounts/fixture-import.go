@@ -0,0 +1,340 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/templates"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/accounts"
+	"github.com/onflow/flowkit/config"
+	"github.com/onflow/flowkit/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsImport struct {
+	Name    string   `default:"fixture" flag:"name" info:"Account name used to save the imported account in the configuration"`
+	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: contracts."`
+}
+
+var importFlags = flagsImport{}
+
+var importCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "import <fixture file>",
+		Short:   "Recreate an account from an exported fixture on the emulator",
+		Example: "flow accounts import fixture.json --name alice",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &importFlags,
+	RunS:  importFixture,
+}
+
+const restoreStorageTransaction = `
+transaction(identifier: String, value: AnyStruct) {
+    prepare(signer: AuthAccount) {
+        signer.save(value, to: StoragePath(identifier: identifier) ?? panic("invalid storage path"))
+    }
+}
+`
+
+// importFixture creates a new emulator account with the fixture keys and an additional generated key
+// which is saved to a key file used by the account in the configuration and is used to deploy the
+// contracts and restore the storage.
+func importFixture(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	if flow.Network().Name != config.EmulatorNetwork.Name {
+		return nil, fmt.Errorf("fixtures can only be imported on the emulator network")
+	}
+
+	data, err := state.ReadFile(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("error loading fixture file: %w", err)
+	}
+
+	var fixture accountFixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture: %w", err)
+	}
+
+	for name, code := range fixture.Contracts {
+		if err := validFixtureContract(name, code); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := state.Accounts().ByName(importFlags.Name); err == nil {
+		return nil, fmt.Errorf("account with name %s already exists in the configuration", importFlags.Name)
+	}
+
+	serviceAccount, err := state.EmulatorServiceAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]accounts.PublicKey, 0, len(fixture.Keys)+1)
+	for _, key := range fixture.Keys {
+		sigAlgos, err := parseSignatureAlgorithms([]string{key.SigAlgo})
+		if err != nil {
+			return nil, err
+		}
+		hashAlgos, err := parseHashingAlgorithms([]string{key.HashAlgo})
+		if err != nil {
+			return nil, err
+		}
+		publicKeys, err := parsePublicKeys([]string{key.PublicKey}, sigAlgos)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, accounts.PublicKey{
+			Public:   publicKeys[0],
+			Weight:   key.Weight,
+			SigAlgo:  sigAlgos[0],
+			HashAlgo: hashAlgos[0],
+		})
+	}
+
+	privateKey, err := flow.GenerateKey(context.Background(), defaultSignAlgo, "")
+	if err != nil {
+		return nil, err
+	}
+	keys = append(keys, accounts.PublicKey{
+		Public:   privateKey.PublicKey(),
+		Weight:   flowsdk.AccountKeyWeightThreshold,
+		SigAlgo:  defaultSignAlgo,
+		HashAlgo: defaultHashAlgo,
+	})
+
+	logger.StartProgress("Creating fixture account...")
+	defer logger.StopProgress()
+
+	created, _, err := flow.CreateAccount(context.Background(), serviceAccount, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	// the created account assigns new key indexes, so the fixture indexes can't be used
+	indexes, err := createdKeyIndexes(created, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	privateFile := accounts.PrivateKeyFile(importFlags.Name, "")
+	err = util.AddToGitIgnore(privateFile, state.ReaderWriter())
+	if err != nil {
+		return nil, err
+	}
+
+	err = state.ReaderWriter().WriteFile(privateFile, []byte(privateKey.String()), os.FileMode(0644))
+	if err != nil {
+		return nil, fmt.Errorf("failed saving private key: %w", err)
+	}
+
+	account := &accounts.Account{
+		Name:    importFlags.Name,
+		Address: created.Address,
+		Key: accounts.NewFileKey(
+			privateFile,
+			indexes[len(keys)-1],
+			defaultSignAlgo,
+			defaultHashAlgo,
+			state.ReaderWriter(),
+		),
+	}
+	state.Accounts().AddOrUpdate(account)
+	if err := state.SaveDefault(); err != nil {
+		return nil, err
+	}
+	logger.StopProgress()
+	logger.Info(fmt.Sprintf("Account 0x%s created and saved as %s.", account.Address, account.Name))
+
+	for i, key := range fixture.Keys {
+		if key.Revoked {
+			_, _, err := sendAccountTransaction(flow, account, templates.RemoveAccountKey(account.Address, indexes[i]))
+			if err != nil {
+				return nil, fmt.Errorf("failed to revoke key %d: %w", indexes[i], err)
+			}
+		}
+	}
+
+	err = deployFixtureContracts(flow, logger, &fixture, account)
+	if err != nil {
+		return nil, err
+	}
+
+	restoreFixtureStorage(flow, logger, &fixture, account)
+
+	err = restoreFixtureBalance(flow, logger, state, &fixture, serviceAccount, account.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := flow.GetAccount(context.Background(), account.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountResult{
+		Account: result,
+		include: importFlags.Include,
+	}, nil
+}
+
+// createdKeyIndexes returns the index of each key on the created account, in the order of the keys.
+//
+// Keys are matched by the public key and each account key is only matched once, so a public key
+// added multiple times is assigned the indexes in order.
+func createdKeyIndexes(created *flowsdk.Account, keys []accounts.PublicKey) ([]int, error) {
+	matched := make(map[int]bool, len(created.Keys))
+	indexes := make([]int, len(keys))
+
+	for i, key := range keys {
+		indexes[i] = -1
+		for _, accountKey := range created.Keys {
+			if !matched[accountKey.Index] && accountKey.PublicKey.Equals(key.Public) {
+				matched[accountKey.Index] = true
+				indexes[i] = accountKey.Index
+				break
+			}
+		}
+
+		if indexes[i] == -1 {
+			return nil, fmt.Errorf("key %s was not found on the created account 0x%s", key.Public, created.Address)
+		}
+	}
+
+	return indexes, nil
+}
+
+// deployFixtureContracts deploys the fixture contracts in the import order with the imports rewritten to the new account.
+func deployFixtureContracts(
+	flow flowkit.Services,
+	logger output.Logger,
+	fixture *accountFixture,
+	account *accounts.Account,
+) error {
+	from := flowsdk.HexToAddress(fixture.Address)
+	order, err := contractDeploymentOrder(fixture.Contracts, from)
+	if err != nil {
+		return err
+	}
+
+	var fromNetwork config.Network
+	if network, err := config.DefaultNetworks.ByName(fixture.Network); err == nil {
+		fromNetwork = *network
+	}
+	fromContracts, err := systemContractsForNetwork(fromNetwork, from)
+	if err != nil {
+		return err
+	}
+	toContracts, err := systemContractsForNetwork(flow.Network(), account.Address)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range order {
+		code := rewriteFixtureImports(fixture.Contracts[name], from, account.Address, fromContracts, toContracts)
+
+		_, _, err := flow.AddContract(
+			context.Background(),
+			account,
+			flowkit.Script{Code: []byte(code), Location: name},
+			flowkit.UpdateExistingContract(false),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to deploy contract %s: %w", name, err)
+		}
+		logger.Info(fmt.Sprintf("Contract %s deployed to 0x%s.", name, account.Address))
+	}
+
+	return nil
+}
+
+// restoreFixtureStorage saves the fixture storage values to the new account, values that can not be restored
+// on the emulator, like structs defined by contracts which are not deployed, are reported and skipped.
+func restoreFixtureStorage(
+	flow flowkit.Services,
+	logger output.Logger,
+	fixture *accountFixture,
+	account *accounts.Account,
+) {
+	for identifier, encoded := range fixture.Storage {
+		value, err := jsoncdc.Decode(nil, encoded)
+		if err == nil {
+			_, _, err = sendAccountTransaction(flow, account, flowsdk.NewTransaction().
+				SetScript([]byte(restoreStorageTransaction)).
+				AddRawArgument(jsoncdc.MustEncode(cadence.String(identifier))).
+				AddRawArgument(jsoncdc.MustEncode(value)))
+		}
+
+		if err != nil {
+			logger.Info(fmt.Sprintf("%s Storage value at /storage/%s was not restored: %s", output.WarningEmoji(), identifier, err))
+			continue
+		}
+		logger.Info(fmt.Sprintf("Storage value at /storage/%s restored.", identifier))
+	}
+}
+
+// restoreFixtureBalance transfers the missing FLOW from the service account so the new account has the fixture balance.
+func restoreFixtureBalance(
+	flow flowkit.Services,
+	logger output.Logger,
+	state *flowkit.State,
+	fixture *accountFixture,
+	serviceAccount *accounts.Account,
+	address flowsdk.Address,
+) error {
+	balance, err := parseTokenAmount(fixture.Balance)
+	if err != nil {
+		return err
+	}
+
+	account, err := flow.GetAccount(context.Background(), address)
+	if err != nil {
+		return err
+	}
+	if cadence.UFix64(account.Balance) >= balance {
+		return nil
+	}
+
+	token, err := resolveFungibleToken(flow, state, serviceAccount.Address, "FlowToken", "", "")
+	if err != nil {
+		return err
+	}
+
+	_, err = sendTokens(flow, logger, serviceAccount, token, balance-cadence.UFix64(account.Balance), address)
+	return err
+}
@@ -0,0 +1,148 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/parser"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go/fvm/systemcontracts"
+)
+
+// accountFixture is the reproducible snapshot of an account state used to recreate the account on the emulator.
+type accountFixture struct {
+	Address   string                     `json:"address"`
+	Network   string                     `json:"network"`
+	Balance   string                     `json:"balance"`
+	Keys      []fixtureKey               `json:"keys"`
+	Contracts map[string]string          `json:"contracts"`
+	Storage   map[string]json.RawMessage `json:"storage,omitempty"`
+}
+
+type fixtureKey struct {
+	Index     int    `json:"index"`
+	PublicKey string `json:"publicKey"`
+	SigAlgo   string `json:"sigAlgo"`
+	HashAlgo  string `json:"hashAlgo"`
+	Weight    int    `json:"weight"`
+	Revoked   bool   `json:"revoked"`
+}
+
+var fixtureImportRegex = regexp.MustCompile(`(?m)^(\s*import\s+)(.+?)(\s+from\s+)0x([0-9a-fA-F]+)`)
+
+// rewriteFixtureImports replaces the imports from the fixture account with imports from the new account
+// and the imports of core contracts with the core contract addresses on the target chain.
+func rewriteFixtureImports(
+	code string,
+	from flowsdk.Address,
+	to flowsdk.Address,
+	fromContracts *systemcontracts.SystemContracts,
+	toContracts *systemcontracts.SystemContracts,
+) string {
+	coreAddresses := make(map[string]string)
+	for _, contract := range fromContracts.All() {
+		for _, target := range toContracts.All() {
+			if target.Name == contract.Name {
+				coreAddresses[fmt.Sprintf("%s.%s", contract.Address.Hex(), contract.Name)] = target.Address.Hex()
+			}
+		}
+	}
+
+	return fixtureImportRegex.ReplaceAllStringFunc(code, func(match string) string {
+		parts := fixtureImportRegex.FindStringSubmatch(match)
+		address := flowsdk.HexToAddress(parts[4])
+
+		if address == from {
+			return fmt.Sprintf("%s%s%s0x%s", parts[1], parts[2], parts[3], to.Hex())
+		}
+
+		name := strings.TrimSpace(strings.Split(parts[2], ",")[0])
+		if target, ok := coreAddresses[fmt.Sprintf("%s.%s", address.Hex(), name)]; ok {
+			return fmt.Sprintf("%s%s%s0x%s", parts[1], parts[2], parts[3], target)
+		}
+
+		return match
+	})
+}
+
+// contractDeploymentOrder sorts the contracts so every contract is deployed after the contracts it imports from the same account.
+func contractDeploymentOrder(contracts map[string]string, address flowsdk.Address) ([]string, error) {
+	names := make([]string, 0, len(contracts))
+	for name := range contracts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	dependencies := make(map[string][]string)
+	for _, name := range names {
+		for _, dependency := range names {
+			if dependency != name && importsContract([]byte(contracts[name]), dependency, address) {
+				dependencies[name] = append(dependencies[name], dependency)
+			}
+		}
+	}
+
+	order := make([]string, 0, len(names))
+	visited := make(map[string]bool)
+	visiting := make(map[string]bool)
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		if visited[name] {
+			return nil
+		}
+		if visiting[name] {
+			return fmt.Errorf("contract %s has a circular import", name)
+		}
+
+		visiting[name] = true
+		for _, dependency := range dependencies[name] {
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+		visiting[name] = false
+		visited[name] = true
+		order = append(order, name)
+
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
+
+// validFixtureContract makes sure the contract code in the fixture can be parsed.
+func validFixtureContract(name string, code string) error {
+	if _, err := parser.ParseProgram(nil, []byte(code), parser.Config{}); err != nil {
+		return fmt.Errorf("invalid code of contract %s in the fixture: %w", name, err)
+	}
+
+	return nil
+}
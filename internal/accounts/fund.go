@@ -19,7 +19,12 @@
 package accounts
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"time"
 
 	flowsdk "github.com/onflow/flow-go-sdk"
@@ -28,40 +33,80 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/config"
 	"github.com/onflow/flowkit/output"
 
 	"github.com/onflow/flow-cli/internal/command"
 )
 
 type flagsFund struct {
-	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: contracts."`
+	Include  []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: contracts."`
+	Amount   string   `default:"1000.0" flag:"amount" info:"Amount of FLOW transferred from the emulator service account"`
+	Headless bool     `default:"false" flag:"headless" info:"Fund the Testnet account through the faucet API instead of opening the browser"`
 }
 
 var fundFlags = flagsFund{}
 
 var fundCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "fund <address>",
-		Short:   "Funds an account by address through the Testnet Faucet",
-		Example: "flow accounts fund 8e94eaa81771313a",
-		Args:    cobra.ExactArgs(1),
+		Use:   "fund <address|account name>",
+		Short: "Funds an account by address through the Testnet Faucet or the emulator service account",
+		Example: `flow accounts fund 8e94eaa81771313a --network testnet
+
+flow accounts fund alice --amount 500`,
+		Args: cobra.ExactArgs(1),
 	},
 	Flags: &fundFlags,
 	Run:   fund,
 }
 
+// testnetFaucetAPIURL is the faucet endpoint used to fund accounts without opening the browser.
+//
+// It is the same endpoint the faucet web application posts to when an account is funded, see
+// https://github.com/onflow/faucet/blob/main/pages/api/fund.ts. The request body is a JSON
+// encoded faucetRequest and any status other than 200 is a failure with the reason in the
+// response body, it is replaced in tests.
+var testnetFaucetAPIURL = "https://testnet-faucet.onflow.org/api/fund"
+
+type faucetRequest struct {
+	Address string `json:"address"`
+	Token   string `json:"token"`
+}
+
 func fund(
 	args []string,
-	_ command.GlobalFlags,
+	globalFlags command.GlobalFlags,
 	logger output.Logger,
-	_ flowkit.ReaderWriter,
+	readerWriter flowkit.ReaderWriter,
 	flow flowkit.Services,
 ) (command.Result, error) {
-	address := flowsdk.HexToAddress(args[0])
+	// the configuration is only required for the emulator service account and resolving account names
+	state, err := flowkit.Load(globalFlags.ConfigPaths, readerWriter)
+	if err != nil && !errors.Is(err, config.ErrDoesNotExist) {
+		return nil, err
+	}
+
+	address, err := resolveAddress(state, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	// testnet addresses keep using the faucet even if the default emulator network is used
+	if flow.Network().Name == config.EmulatorNetwork.Name && address.IsValid(flowsdk.Emulator) {
+		if state == nil {
+			return nil, config.ErrDoesNotExist
+		}
+		return fundEmulator(flow, logger, state, address)
+	}
+
 	if !address.IsValid(flowsdk.Testnet) {
 		return nil, fmt.Errorf("unsupported address %s, faucet can only work for valid Testnet addresses", address.String())
 	}
 
+	if fundFlags.Headless {
+		return nil, fundTestnetHeadless(logger, address)
+	}
+
 	logger.Info(
 		fmt.Sprintf(
 			"Opening the Testnet faucet to fund 0x%s on your native browser."+
@@ -78,3 +123,63 @@ func fund(
 
 	return nil, nil
 }
+
+// fundEmulator transfers FLOW from the emulator service account to the address.
+func fundEmulator(
+	flow flowkit.Services,
+	logger output.Logger,
+	state *flowkit.State,
+	address flowsdk.Address,
+) (command.Result, error) {
+	amount, err := parseTokenAmount(fundFlags.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceAccount, err := state.EmulatorServiceAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := resolveFungibleToken(flow, state, serviceAccount.Address, "FlowToken", "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	return sendTokens(flow, logger, serviceAccount, token, amount, address)
+}
+
+// fundTestnetHeadless requests the funds from the Testnet faucet API, which makes funding usable in CI.
+func fundTestnetHeadless(logger output.Logger, address flowsdk.Address) error {
+	data, err := json.Marshal(faucetRequest{
+		Address: fmt.Sprintf("0x%s", address),
+		Token:   "FLOW",
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.StartProgress(fmt.Sprintf("Funding 0x%s through the Testnet faucet...", address))
+	defer logger.StopProgress()
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	res, err := client.Post(testnetFaucetAPIURL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("could not fund the account: %w", err)
+	}
+	defer res.Body.Close()
+
+	body, _ := io.ReadAll(res.Body)
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf(
+			"could not fund the account, faucet responded with status %d: %s",
+			res.StatusCode,
+			bytes.TrimSpace(body),
+		)
+	}
+
+	logger.StopProgress()
+	logger.Info(fmt.Sprintf("%s Account 0x%s funded through the Testnet faucet.", output.SuccessEmoji(), address))
+
+	return nil
+}
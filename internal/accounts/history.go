@@ -0,0 +1,367 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsHistory struct {
+	Start   uint64 `flag:"start" info:"Start block height"`
+	End     uint64 `flag:"end" info:"End block height"`
+	Last    uint64 `default:"100" flag:"last" info:"Scan number of blocks relative to the last block. Ignored if the start flag is set"`
+	Workers int    `default:"10" flag:"workers" info:"Number of workers to use when scanning blocks in parallel"`
+}
+
+var historyFlags = flagsHistory{}
+
+// historyStartChanged and historyEndChanged are set when the range flags are provided, since 0 is a valid height.
+var historyStartChanged, historyEndChanged bool
+
+var historyCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "history <address|account name>",
+		Short: "List the transactions and events involving an account over a block range",
+		Example: `flow accounts history 0x01cf0e2f2f715450 --last 1000 --network testnet
+
+flow accounts history alice --start 11559500 --end 11559600`,
+		Args: cobra.ExactArgs(1),
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			historyStartChanged = cmd.Flags().Changed("start")
+			historyEndChanged = cmd.Flags().Changed("end")
+		},
+	},
+	Flags: &historyFlags,
+	RunS:  history,
+}
+
+func history(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	address, err := resolveAddress(state, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if historyFlags.Workers <= 0 {
+		return nil, fmt.Errorf("workers must be a positive number")
+	}
+
+	var start, end *uint64
+	if historyStartChanged {
+		start = &historyFlags.Start
+	}
+	if historyEndChanged {
+		end = &historyFlags.End
+	}
+
+	first, last, err := historyRange(flow, start, end, historyFlags.Last)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.StartProgress(fmt.Sprintf("Scanning blocks %d to %d for 0x%s...", first, last, address))
+	defer logger.StopProgress()
+
+	entries, err := scanHistory(flow, address, first, last, historyFlags.Workers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &historyResult{
+		address: address,
+		start:   first,
+		end:     last,
+		entries: entries,
+	}, nil
+}
+
+// historyRange returns the block range to scan, defaulting to the last blocks including the latest block
+// when the range is not provided.
+func historyRange(flow flowkit.Services, start *uint64, end *uint64, last uint64) (uint64, uint64, error) {
+	if start != nil && end != nil {
+		if *start > *end {
+			return 0, 0, fmt.Errorf("start height %d must not be greater than end height %d", *start, *end)
+		}
+		return *start, *end, nil
+	}
+	if start != nil || end != nil {
+		return 0, 0, fmt.Errorf("please provide either both start and end for range or only last flag")
+	}
+	if last == 0 {
+		return 0, 0, fmt.Errorf("last must be a positive number")
+	}
+
+	latest, err := flow.GetBlock(context.Background(), flowkit.BlockQuery{Latest: true})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if latest.Height < last {
+		return 0, latest.Height, nil
+	}
+	return latest.Height - last + 1, latest.Height, nil
+}
+
+// historyEntry is a transaction involving the account, either by signing it or by emitting events mentioning it.
+type historyEntry struct {
+	height    uint64
+	timestamp time.Time
+	index     int
+	id        flowsdk.Identifier
+	roles     []string
+	status    flowsdk.TransactionStatus
+	err       error
+	events    []string
+}
+
+// scanHistory fetches the transactions of every block in the range using a pool of workers
+// and returns the transactions involving the address in chronological order.
+func scanHistory(
+	flow flowkit.Services,
+	address flowsdk.Address,
+	start uint64,
+	end uint64,
+	workers int,
+) ([]historyEntry, error) {
+	var (
+		mu      sync.Mutex
+		entries = make([]historyEntry, 0)
+	)
+
+	err := util.RunWorkers(int(end-start+1), workers, func(i int) error {
+		found, err := scanBlock(flow, address, start+uint64(i))
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		entries = append(entries, found...)
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].height != entries[j].height {
+			return entries[i].height < entries[j].height
+		}
+		return entries[i].index < entries[j].index
+	})
+
+	return entries, nil
+}
+
+// scanBlock returns the transactions in the block at the height involving the address.
+func scanBlock(flow flowkit.Services, address flowsdk.Address, height uint64) ([]historyEntry, error) {
+	block, err := flow.GetBlock(context.Background(), flowkit.BlockQuery{Height: height})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %d: %w", height, err)
+	}
+
+	txs, results, err := flow.GetTransactionsByBlockID(context.Background(), block.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions of block %d: %w", height, err)
+	}
+
+	entries := make([]historyEntry, 0)
+	for i, tx := range txs {
+		entry := historyEntry{
+			height:    block.Height,
+			timestamp: block.Timestamp,
+			index:     i,
+			id:        tx.ID(),
+			roles:     transactionRoles(tx, address),
+		}
+
+		if i < len(results) && results[i] != nil {
+			entry.status = results[i].Status
+			entry.err = results[i].Error
+			for _, event := range results[i].Events {
+				if mentionsAddress(event.Value, address) {
+					entry.events = append(entry.events, event.Type)
+				}
+			}
+		}
+
+		if len(entry.roles) > 0 || len(entry.events) > 0 {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+// transactionRoles returns the roles the address has in the transaction.
+func transactionRoles(tx *flowsdk.Transaction, address flowsdk.Address) []string {
+	roles := make([]string, 0)
+	if tx.Payer == address {
+		roles = append(roles, "payer")
+	}
+	if tx.ProposalKey.Address == address {
+		roles = append(roles, "proposer")
+	}
+	for _, authorizer := range tx.Authorizers {
+		if authorizer == address {
+			roles = append(roles, "authorizer")
+			break
+		}
+	}
+
+	return roles
+}
+
+// mentionsAddress reports whether the address is contained anywhere in the value.
+func mentionsAddress(value cadence.Value, address flowsdk.Address) bool {
+	switch v := value.(type) {
+	case cadence.Address:
+		return flowsdk.Address(v) == address
+	case cadence.Optional:
+		return v.Value != nil && mentionsAddress(v.Value, address)
+	case cadence.Array:
+		for _, element := range v.Values {
+			if mentionsAddress(element, address) {
+				return true
+			}
+		}
+	case cadence.Dictionary:
+		for _, pair := range v.Pairs {
+			if mentionsAddress(pair.Key, address) || mentionsAddress(pair.Value, address) {
+				return true
+			}
+		}
+	case cadence.Event:
+		return fieldsMentionAddress(v.Fields, address)
+	case cadence.Struct:
+		return fieldsMentionAddress(v.Fields, address)
+	case cadence.Resource:
+		return fieldsMentionAddress(v.Fields, address)
+	}
+
+	return false
+}
+
+func fieldsMentionAddress(fields []cadence.Value, address flowsdk.Address) bool {
+	for _, field := range fields {
+		if mentionsAddress(field, address) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type historyResult struct {
+	address flowsdk.Address
+	start   uint64
+	end     uint64
+	entries []historyEntry
+}
+
+func historyStatus(entry historyEntry) string {
+	if entry.err != nil {
+		return "FAILED"
+	}
+
+	return entry.status.String()
+}
+
+func (r *historyResult) JSON() any {
+	entries := make([]any, 0, len(r.entries))
+	for _, entry := range r.entries {
+		result := map[string]any{
+			"height":    entry.height,
+			"timestamp": entry.timestamp,
+			"id":        entry.id.String(),
+			"roles":     entry.roles,
+			"status":    historyStatus(entry),
+			"events":    entry.events,
+		}
+		if entry.err != nil {
+			result["error"] = entry.err.Error()
+		}
+		entries = append(entries, result)
+	}
+
+	return map[string]any{
+		"address":      fmt.Sprintf("0x%s", r.address),
+		"start":        r.start,
+		"end":          r.end,
+		"transactions": entries,
+	}
+}
+
+func (r *historyResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Address\t 0x%s\n", r.address)
+	_, _ = fmt.Fprintf(writer, "Blocks\t %d to %d\n", r.start, r.end)
+	_, _ = fmt.Fprintf(writer, "Transactions\t %d\n", len(r.entries))
+
+	for _, entry := range r.entries {
+		_, _ = fmt.Fprintf(writer, "\nHeight\t %d\n", entry.height)
+		_, _ = fmt.Fprintf(writer, "Time\t %s\n", entry.timestamp.UTC().Format(time.RFC3339))
+		_, _ = fmt.Fprintf(writer, "ID\t %s\n", entry.id)
+		_, _ = fmt.Fprintf(writer, "Status\t %s\n", historyStatus(entry))
+		if entry.err != nil {
+			_, _ = fmt.Fprintf(writer, "Error\t %s\n", entry.err)
+		}
+		if len(entry.roles) > 0 {
+			_, _ = fmt.Fprintf(writer, "Roles\t %s\n", strings.Join(entry.roles, ", "))
+		}
+		for _, event := range entry.events {
+			_, _ = fmt.Fprintf(writer, "Event\t %s\n", event)
+		}
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *historyResult) Oneliner() string {
+	ids := make([]string, 0, len(r.entries))
+	for _, entry := range r.entries {
+		ids = append(ids, entry.id.String())
+	}
+
+	return fmt.Sprintf("Address: 0x%s, Transactions: %s", r.address, strings.Join(ids, ", "))
+}
@@ -0,0 +1,117 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"context"
+	"fmt"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/templates"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsAddKey struct {
+	Signer   string   `default:"emulator-account" flag:"signer" info:"Account name from configuration used to sign the transaction"`
+	SigAlgo  string   `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm of the public key"`
+	HashAlgo string   `default:"SHA3_256" flag:"hash-algo" info:"Hash algorithm to pair with the public key"`
+	Weight   int      `default:"1000" flag:"weight" info:"Weight for the key"`
+	Include  []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: contracts."`
+}
+
+var addKeyFlags = flagsAddKey{}
+
+var addKeyCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "add <public key>",
+		Short:   "Add a public key to an account",
+		Example: "flow accounts keys add 0x2a0f...ef2 --weight 500 --signer alice",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &addKeyFlags,
+	RunS:  addKey,
+}
+
+func addKey(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	signer, err := state.Accounts().ByName(addKeyFlags.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	if addKeyFlags.Weight < 0 || addKeyFlags.Weight > flowsdk.AccountKeyWeightThreshold {
+		return nil, fmt.Errorf("invalid key weight %d, must be between 0 and %d", addKeyFlags.Weight, flowsdk.AccountKeyWeightThreshold)
+	}
+
+	sigAlgos, err := parseSignatureAlgorithms([]string{addKeyFlags.SigAlgo})
+	if err != nil {
+		return nil, err
+	}
+
+	hashAlgos, err := parseHashingAlgorithms([]string{addKeyFlags.HashAlgo})
+	if err != nil {
+		return nil, err
+	}
+
+	pubKeys, err := parsePublicKeys(args[:1], sigAlgos)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := templates.AddAccountKey(signer.Address, &flowsdk.AccountKey{
+		PublicKey: pubKeys[0],
+		SigAlgo:   sigAlgos[0],
+		HashAlgo:  hashAlgos[0],
+		Weight:    addKeyFlags.Weight,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sentTx, _, err := sendAccountTransaction(flow, signer, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(fmt.Sprintf(
+		"Key added to account 0x%s with transaction ID: %s.",
+		signer.Address,
+		sentTx.ID(),
+	))
+
+	account, err := flow.GetAccount(context.Background(), signer.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountResult{
+		Account: account,
+		include: addKeyFlags.Include,
+	}, nil
+}
@@ -0,0 +1,110 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/onflow/flow-go-sdk/templates"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsRevokeKey struct {
+	Signer  string   `default:"emulator-account" flag:"signer" info:"Account name from configuration used to sign the transaction"`
+	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: contracts."`
+}
+
+var revokeKeyFlags = flagsRevokeKey{}
+
+var revokeKeyCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "revoke <key index>",
+		Short:   "Revoke a key on an account",
+		Example: "flow accounts keys revoke 1 --signer alice",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &revokeKeyFlags,
+	RunS:  revokeKey,
+}
+
+func revokeKey(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	index, err := strconv.Atoi(args[0])
+	if err != nil || index < 0 {
+		return nil, fmt.Errorf("invalid key index %s, must be a positive number", args[0])
+	}
+
+	signer, err := state.Accounts().ByName(revokeKeyFlags.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := flow.GetAccount(context.Background(), signer.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkRevokeWeight(account, index)
+	if err != nil {
+		return nil, err
+	}
+
+	if signer.Key.Index() == index {
+		logger.Info(fmt.Sprintf(
+			"%s Key %d is used by account %s in the configuration, it will no longer be able to sign transactions.",
+			output.WarningEmoji(),
+			index,
+			signer.Name,
+		))
+	}
+
+	sentTx, _, err := sendAccountTransaction(flow, signer, templates.RemoveAccountKey(signer.Address, index))
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(fmt.Sprintf(
+		"Key %d revoked on account 0x%s with transaction ID: %s.",
+		index,
+		signer.Address,
+		sentTx.ID(),
+	))
+
+	account, err = flow.GetAccount(context.Background(), signer.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountResult{
+		Account: account,
+		include: revokeKeyFlags.Include,
+	}, nil
+}
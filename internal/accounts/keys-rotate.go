@@ -0,0 +1,234 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go-sdk/templates"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/accounts"
+	"github.com/onflow/flowkit/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsRotateKey struct {
+	Signer  string   `default:"emulator-account" flag:"signer" info:"Account name from configuration which key will be rotated"`
+	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: contracts."`
+}
+
+var rotateKeyFlags = flagsRotateKey{}
+
+var rotateKeyCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "rotate",
+		Short:   "Replace the configured account key with a newly generated key",
+		Example: "flow accounts keys rotate --signer alice",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &rotateKeyFlags,
+	RunS:  rotateKey,
+}
+
+// rotateKey generates a new key and adds it to the account with the same weight as the currently
+// configured key, it then updates the configuration to use the new key and revokes the old key
+// with a transaction signed by the new key.
+//
+// Only hex keys and private key files can be rotated, since the new private key is stored in the
+// same way as the old key.
+func rotateKey(
+	_ []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	signer, err := state.Accounts().ByName(rotateKeyFlags.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	switch signer.Key.(type) {
+	case *accounts.HexKey, *accounts.FileKey:
+	default:
+		return nil, fmt.Errorf(
+			"rotating the key of account %s is not supported, only hex keys and private key files can be rotated",
+			signer.Name,
+		)
+	}
+
+	account, err := flow.GetAccount(context.Background(), signer.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	oldKey, err := findAccountKey(account, signer.Key.Index())
+	if err != nil {
+		return nil, err
+	}
+	if oldKey.Revoked {
+		return nil, fmt.Errorf("key with index %d is already revoked", oldKey.Index)
+	}
+
+	// the new key gets the weight of the old key, so the weight remaining after the old key is revoked
+	// is checked before anything is changed
+	rotatedAccount := *account
+	rotatedAccount.Keys = append(append([]*flowsdk.AccountKey{}, account.Keys...), &flowsdk.AccountKey{
+		Index:  len(account.Keys),
+		Weight: oldKey.Weight,
+	})
+	err = checkRevokeWeight(&rotatedAccount, oldKey.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := flow.GenerateKey(context.Background(), oldKey.SigAlgo, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// the new key is saved before it is added to the account so it can't be lost if a later step fails,
+	// new keys get the next index on the account which is used to name the key file
+	newKeyLocation, err := saveRotatedKey(state, signer, len(account.Keys), privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := templates.AddAccountKey(signer.Address, &flowsdk.AccountKey{
+		PublicKey: privateKey.PublicKey(),
+		SigAlgo:   oldKey.SigAlgo,
+		HashAlgo:  oldKey.HashAlgo,
+		Weight:    oldKey.Weight,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sentTx, _, err := sendAccountTransaction(flow, signer, tx)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info(fmt.Sprintf("New key added to account 0x%s with transaction ID: %s.", signer.Address, sentTx.ID()))
+
+	account, err = flow.GetAccount(context.Background(), signer.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	newIndex := -1
+	for _, key := range account.Keys {
+		if key.PublicKey.Equals(privateKey.PublicKey()) && !key.Revoked {
+			newIndex = key.Index
+		}
+	}
+	if newIndex == -1 {
+		return nil, fmt.Errorf("new key was not found on account 0x%s", signer.Address)
+	}
+
+	newKey := rotatedAccountKey(state, newKeyLocation, newIndex, oldKey.HashAlgo, privateKey)
+	rotated := &accounts.Account{
+		Name:    signer.Name,
+		Address: signer.Address,
+		Key:     newKey,
+	}
+	state.Accounts().AddOrUpdate(rotated)
+
+	err = state.SaveDefault()
+	if err != nil {
+		return nil, err
+	}
+	logger.Info(fmt.Sprintf("Account %s updated in the configuration to use key %d.", signer.Name, newIndex))
+
+	sentTx, _, err = sendAccountTransaction(flow, rotated, templates.RemoveAccountKey(signer.Address, oldKey.Index))
+	if err != nil {
+		return nil, fmt.Errorf("new key is configured but revoking the old key %d failed: %w", oldKey.Index, err)
+	}
+	logger.Info(fmt.Sprintf("Old key %d revoked with transaction ID: %s.", oldKey.Index, sentTx.ID()))
+
+	account, err = flow.GetAccount(context.Background(), signer.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountResult{
+		Account: account,
+		include: rotateKeyFlags.Include,
+	}, nil
+}
+
+// saveRotatedKey saves the new private key to a new key file next to the old key file and returns
+// its location, the old key file is left unchanged. Nothing is saved for keys stored in the
+// configuration and an empty location is returned.
+func saveRotatedKey(
+	state *flowkit.State,
+	signer *accounts.Account,
+	index int,
+	privateKey crypto.PrivateKey,
+) (string, error) {
+	if _, ok := signer.Key.(*accounts.FileKey); !ok {
+		return "", nil
+	}
+
+	location := accounts.PrivateKeyFile(
+		fmt.Sprintf("%s-%d", signer.Name, index),
+		filepath.Dir(signer.Key.ToConfig().Location),
+	)
+	if _, err := state.ReaderWriter().ReadFile(location); err == nil {
+		return "", fmt.Errorf("key file %s already exists", location)
+	}
+
+	err := util.AddToGitIgnore(location, state.ReaderWriter())
+	if err != nil {
+		return "", err
+	}
+
+	err = state.ReaderWriter().WriteFile(location, []byte(privateKey.String()), os.FileMode(0644))
+	if err != nil {
+		return "", fmt.Errorf("failed saving private key: %w", err)
+	}
+
+	return location, nil
+}
+
+// rotatedAccountKey creates the configuration key for the newly generated private key.
+//
+// If the new key was saved to a key file the file is referenced, otherwise the new key is stored
+// in the configuration in the hex format like the old key.
+func rotatedAccountKey(
+	state *flowkit.State,
+	location string,
+	index int,
+	hashAlgo crypto.HashAlgorithm,
+	privateKey crypto.PrivateKey,
+) accounts.Key {
+	if location == "" {
+		return accounts.NewHexKeyFromPrivateKey(index, hashAlgo, privateKey)
+	}
+
+	return accounts.NewFileKey(location, index, privateKey.Algorithm(), hashAlgo, state.ReaderWriter())
+}
@@ -0,0 +1,120 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/accounts"
+	"github.com/onflow/flowkit/transactions"
+)
+
+var keysCmd = &cobra.Command{
+	Use:              "keys <add|revoke|rotate>",
+	Short:            "Manage keys on an existing account",
+	Example:          "flow accounts keys add 0x2a0f...ef2 --signer alice",
+	Args:             cobra.ExactArgs(1),
+	TraverseChildren: true,
+}
+
+func init() {
+	addKeyCommand.AddToParent(keysCmd)
+	revokeKeyCommand.AddToParent(keysCmd)
+	rotateKeyCommand.AddToParent(keysCmd)
+}
+
+// sendAccountTransaction signs the provided transaction template with the signer account,
+// which acts as the proposer, payer and single authorizer, and waits for the result.
+func sendAccountTransaction(
+	flow flowkit.Services,
+	signer *accounts.Account,
+	tx *flowsdk.Transaction,
+) (*flowsdk.Transaction, *flowsdk.TransactionResult, error) {
+	args := make([]cadence.Value, 0, len(tx.Arguments))
+	for _, raw := range tx.Arguments {
+		arg, err := jsoncdc.Decode(nil, raw)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to decode transaction argument: %w", err)
+		}
+		args = append(args, arg)
+	}
+
+	sentTx, result, err := flow.SendTransaction(
+		context.Background(),
+		transactions.SingleAccountRole(*signer),
+		flowkit.Script{Code: tx.Script, Args: args},
+		flowsdk.DefaultTransactionGasLimit,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if result != nil && result.Error != nil {
+		return nil, nil, fmt.Errorf("transaction %s failed: %w", sentTx.ID(), result.Error)
+	}
+
+	return sentTx, result, nil
+}
+
+// findAccountKey returns the key with the provided index on the account.
+func findAccountKey(account *flowsdk.Account, index int) (*flowsdk.AccountKey, error) {
+	for _, key := range account.Keys {
+		if key.Index == index {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("key with index %d does not exist on account 0x%s", index, account.Address)
+}
+
+// checkRevokeWeight makes sure that after revoking the key with the provided index the
+// remaining non-revoked keys still have enough weight to sign transactions for the account.
+func checkRevokeWeight(account *flowsdk.Account, index int) error {
+	key, err := findAccountKey(account, index)
+	if err != nil {
+		return err
+	}
+	if key.Revoked {
+		return fmt.Errorf("key with index %d is already revoked", index)
+	}
+
+	remaining := 0
+	for _, k := range account.Keys {
+		if k.Index != index && !k.Revoked {
+			remaining += k.Weight
+		}
+	}
+
+	if remaining < flowsdk.AccountKeyWeightThreshold {
+		return fmt.Errorf(
+			"refusing to revoke key %d: remaining key weight %d would be below the threshold of %d",
+			index,
+			remaining,
+			flowsdk.AccountKeyWeightThreshold,
+		)
+	}
+
+	return nil
+}
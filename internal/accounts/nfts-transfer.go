@@ -0,0 +1,193 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/output"
+	"github.com/onflow/flowkit/transactions"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsNFTsTransfer struct {
+	Signer       string `default:"emulator-account" flag:"signer" info:"Account name from configuration used to sign the transaction and send the NFT"`
+	ReceiverPath string `default:"" flag:"receiver-path" info:"Public path identifier of the recipient collection, resolved from the NFT collection data view by default"`
+}
+
+var nftsTransferFlags = flagsNFTsTransfer{}
+
+var nftsTransferCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "transfer <collection storage path> <id> <address|account name>",
+		Short:   "Transfer an NFT to an account",
+		Example: "flow accounts nfts transfer exampleNFTCollection 42 0x179b6b1cb6755e31 --signer alice",
+		Args:    cobra.ExactArgs(3),
+	},
+	Flags: &nftsTransferFlags,
+	RunS:  nftsTransfer,
+}
+
+// transferNFTTransaction withdraws the NFT from the signer collection and deposits it to the recipient collection,
+// the recipient collection public path is resolved from the collection data view unless it is provided.
+const transferNFTTransaction = `
+import NonFungibleToken from 0x%s
+import MetadataViews from 0x%s
+
+transaction(collectionPath: String, id: UInt64, to: Address, receiverPath: String?) {
+    let nft: @NonFungibleToken.NFT
+    let publicPath: PublicPath
+
+    prepare(signer: AuthAccount) {
+        let storagePath = StoragePath(identifier: collectionPath) ?? panic("invalid collection path")
+        let provider = signer.borrow<&{NonFungibleToken.Provider}>(from: storagePath)
+            ?? panic("could not borrow the collection of the signer")
+
+        var publicPath: PublicPath? = nil
+        if receiverPath != nil {
+            publicPath = PublicPath(identifier: receiverPath!)
+        } else if let resolver = signer.borrow<&{MetadataViews.ResolverCollection}>(from: storagePath) {
+            let view = resolver.borrowViewResolver(id: id).resolveView(Type<MetadataViews.NFTCollectionData>())
+            if let data = view as? MetadataViews.NFTCollectionData {
+                publicPath = data.publicPath
+            }
+        }
+
+        self.publicPath = publicPath ?? panic("could not resolve the recipient collection path, provide the receiver path")
+        self.nft <- provider.withdraw(withdrawID: id)
+    }
+
+    execute {
+        let receiver = getAccount(to).getCapability(self.publicPath).borrow<&{NonFungibleToken.CollectionPublic}>()
+            ?? panic("could not borrow the collection of the recipient")
+
+        receiver.deposit(token: <-self.nft)
+    }
+}
+`
+
+func nftsTransfer(
+	args []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	collectionPath := strings.TrimPrefix(args[0], "/storage/")
+
+	id, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NFT ID %s, must be a positive number", args[1])
+	}
+
+	to, err := resolveAddress(state, args[2])
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := state.Accounts().ByName(nftsTransferFlags.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	sc, err := systemContractsForNetwork(flow.Network(), signer.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	var receiverPath cadence.Value = cadence.NewOptional(nil)
+	if nftsTransferFlags.ReceiverPath != "" {
+		receiverPath = cadence.NewOptional(cadence.String(strings.TrimPrefix(nftsTransferFlags.ReceiverPath, "/public/")))
+	}
+
+	tx, result, err := flow.SendTransaction(
+		context.Background(),
+		transactions.SingleAccountRole(*signer),
+		flowkit.Script{
+			Code: []byte(fmt.Sprintf(transferNFTTransaction, sc.NonFungibleToken.Address, sc.MetadataViews.Address)),
+			Args: []cadence.Value{
+				cadence.String(collectionPath),
+				cadence.NewUInt64(id),
+				cadence.NewAddress(to),
+				receiverPath,
+			},
+		},
+		flowsdk.DefaultTransactionGasLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("NFT transfer transaction %s failed: %w", tx.ID(), result.Error)
+	}
+
+	return &nftTransferResult{
+		id:         tx.ID(),
+		collection: collectionPath,
+		nftID:      id,
+		from:       signer.Address,
+		to:         to,
+	}, nil
+}
+
+type nftTransferResult struct {
+	id         flowsdk.Identifier
+	collection string
+	nftID      uint64
+	from       flowsdk.Address
+	to         flowsdk.Address
+}
+
+func (r *nftTransferResult) JSON() any {
+	return map[string]any{
+		"id":         r.id.String(),
+		"collection": r.collection,
+		"nftId":      r.nftID,
+		"from":       fmt.Sprintf("0x%s", r.from),
+		"to":         fmt.Sprintf("0x%s", r.to),
+	}
+}
+
+func (r *nftTransferResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "%s Transferred NFT %d from /storage/%s\n\n", output.SuccessEmoji(), r.nftID, r.collection)
+	_, _ = fmt.Fprintf(writer, "ID\t %s\n", r.id)
+	_, _ = fmt.Fprintf(writer, "From\t 0x%s\n", r.from)
+	_, _ = fmt.Fprintf(writer, "To\t 0x%s\n", r.to)
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *nftTransferResult) Oneliner() string {
+	return fmt.Sprintf("ID: %s, NFT: %d, From: 0x%s, To: 0x%s", r.id, r.nftID, r.from, r.to)
+}
@@ -0,0 +1,313 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsNFTs struct{}
+
+var nftsFlags = flagsNFTs{}
+
+var nftsCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "nfts <address|account name>",
+		Short: "List NFTs held by an account",
+		Example: `flow accounts nfts 0x01cf0e2f2f715450
+
+flow accounts nfts transfer exampleNFTCollection 42 0x179b6b1cb6755e31 --signer alice`,
+		Args:             cobra.ExactArgs(1),
+		TraverseChildren: true,
+	},
+	Flags: &nftsFlags,
+	RunS:  nfts,
+}
+
+func init() {
+	nftsTransferCommand.AddToParent(nftsCommand.Cmd)
+}
+
+// listNFTsScript finds all NFT collections in the account storage and resolves
+// the display view of every NFT in the collection if the collection supports metadata views.
+const listNFTsScript = `
+import NonFungibleToken from 0x%s
+import MetadataViews from 0x%s
+
+pub struct NFTInfo {
+    pub let id: UInt64
+    pub let name: String?
+    pub let thumbnail: String?
+
+    init(id: UInt64, name: String?, thumbnail: String?) {
+        self.id = id
+        self.name = name
+        self.thumbnail = thumbnail
+    }
+}
+
+pub struct CollectionInfo {
+    pub let path: String
+    pub let typeIdentifier: String
+    pub let nfts: [NFTInfo]
+
+    init(path: String, typeIdentifier: String, nfts: [NFTInfo]) {
+        self.path = path
+        self.typeIdentifier = typeIdentifier
+        self.nfts = nfts
+    }
+}
+
+pub fun main(address: Address): [CollectionInfo] {
+    let account = getAuthAccount(address)
+    let collections: [CollectionInfo] = []
+    let collectionType = Type<@{NonFungibleToken.CollectionPublic}>()
+
+    account.forEachStored(fun (path: StoragePath, type: Type): Bool {
+        if !type.isSubtype(of: collectionType) {
+            return true
+        }
+
+        let collection = account.borrow<&{NonFungibleToken.CollectionPublic}>(from: path)!
+        let resolver = account.borrow<&{MetadataViews.ResolverCollection}>(from: path)
+
+        let nfts: [NFTInfo] = []
+        for id in collection.getIDs() {
+            var name: String? = nil
+            var thumbnail: String? = nil
+
+            if resolver != nil {
+                if let display = MetadataViews.getDisplay(resolver!.borrowViewResolver(id: id)) {
+                    name = display.name
+                    thumbnail = display.thumbnail.uri()
+                }
+            }
+
+            nfts.append(NFTInfo(id: id, name: name, thumbnail: thumbnail))
+        }
+
+        collections.append(CollectionInfo(path: path.toString(), typeIdentifier: type.identifier, nfts: nfts))
+        return true
+    })
+
+    return collections
+}
+`
+
+func nfts(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	address, err := resolveAddress(state, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	sc, err := systemContractsForNetwork(flow.Network(), address)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.StartProgress(fmt.Sprintf("Fetching NFTs for 0x%s...", address))
+	defer logger.StopProgress()
+
+	value, err := flow.ExecuteScript(
+		context.Background(),
+		flowkit.Script{
+			Code: []byte(fmt.Sprintf(listNFTsScript, sc.NonFungibleToken.Address, sc.MetadataViews.Address)),
+			Args: []cadence.Value{cadence.NewAddress(address)},
+		},
+		flowkit.LatestScriptQuery,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list NFTs: %w", err)
+	}
+
+	collections, err := newNFTCollectionsFromValue(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse NFTs: %w", err)
+	}
+
+	return &nftsResult{address: address, collections: collections}, nil
+}
+
+type nftInfo struct {
+	id        uint64
+	name      string
+	thumbnail string
+}
+
+type nftCollection struct {
+	path           string
+	typeIdentifier string
+	nfts           []nftInfo
+}
+
+// structFields maps the struct field names to the values.
+func structFields(value cadence.Value) (map[string]cadence.Value, error) {
+	s, ok := value.(cadence.Struct)
+	if !ok {
+		return nil, fmt.Errorf("value must be a cadence struct")
+	}
+
+	fields := make(map[string]cadence.Value)
+	for i, field := range s.StructType.Fields {
+		fields[field.Identifier] = s.Fields[i]
+	}
+
+	return fields, nil
+}
+
+// optionalString returns the string value of an optional cadence string or an empty string.
+func optionalString(value cadence.Value) string {
+	if optional, ok := value.(cadence.Optional); ok {
+		value = optional.Value
+	}
+	if s, ok := value.(cadence.String); ok {
+		return string(s)
+	}
+
+	return ""
+}
+
+func newNFTCollectionsFromValue(value cadence.Value) ([]nftCollection, error) {
+	array, ok := value.(cadence.Array)
+	if !ok {
+		return nil, fmt.Errorf("collections must be a cadence array")
+	}
+
+	collections := make([]nftCollection, 0, len(array.Values))
+	for _, v := range array.Values {
+		fields, err := structFields(v)
+		if err != nil {
+			return nil, err
+		}
+
+		ids, ok := fields["nfts"].(cadence.Array)
+		if !ok {
+			return nil, fmt.Errorf("collection NFTs must be a cadence array")
+		}
+
+		nfts := make([]nftInfo, 0, len(ids.Values))
+		for _, n := range ids.Values {
+			nftFields, err := structFields(n)
+			if err != nil {
+				return nil, err
+			}
+
+			id, ok := nftFields["id"].(cadence.UInt64)
+			if !ok {
+				return nil, fmt.Errorf("NFT ID must be a cadence UInt64")
+			}
+
+			nfts = append(nfts, nftInfo{
+				id:        uint64(id),
+				name:      optionalString(nftFields["name"]),
+				thumbnail: optionalString(nftFields["thumbnail"]),
+			})
+		}
+
+		collections = append(collections, nftCollection{
+			path:           optionalString(fields["path"]),
+			typeIdentifier: optionalString(fields["typeIdentifier"]),
+			nfts:           nfts,
+		})
+	}
+
+	return collections, nil
+}
+
+type nftsResult struct {
+	address     flowsdk.Address
+	collections []nftCollection
+}
+
+func (r *nftsResult) JSON() any {
+	collections := make([]any, 0, len(r.collections))
+	for _, collection := range r.collections {
+		nfts := make([]any, 0, len(collection.nfts))
+		for _, nft := range collection.nfts {
+			nfts = append(nfts, map[string]any{
+				"id":        nft.id,
+				"name":      nft.name,
+				"thumbnail": nft.thumbnail,
+			})
+		}
+
+		collections = append(collections, map[string]any{
+			"path": collection.path,
+			"type": collection.typeIdentifier,
+			"nfts": nfts,
+		})
+	}
+
+	return map[string]any{
+		"address":     fmt.Sprintf("0x%s", r.address),
+		"collections": collections,
+	}
+}
+
+func (r *nftsResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Address\t 0x%s\n", r.address)
+	_, _ = fmt.Fprintf(writer, "Collections\t %d\n", len(r.collections))
+
+	for _, collection := range r.collections {
+		_, _ = fmt.Fprintf(writer, "\nCollection\t %s\n", collection.typeIdentifier)
+		_, _ = fmt.Fprintf(writer, "Path\t %s\n", collection.path)
+		_, _ = fmt.Fprintf(writer, "NFTs\t %d\n", len(collection.nfts))
+
+		for _, nft := range collection.nfts {
+			_, _ = fmt.Fprintf(writer, "    ID %d\t %s\t %s\n", nft.id, nft.name, nft.thumbnail)
+		}
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *nftsResult) Oneliner() string {
+	result := fmt.Sprintf("Address: 0x%s", r.address)
+	for _, collection := range r.collections {
+		ids := make([]uint64, 0, len(collection.nfts))
+		for _, nft := range collection.nfts {
+			ids = append(ids, nft.id)
+		}
+		result += fmt.Sprintf(", %s: %v", collection.path, ids)
+	}
+
+	return result
+}
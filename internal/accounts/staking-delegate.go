@@ -0,0 +1,82 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"github.com/onflow/cadence"
+	tmpl "github.com/onflow/flow-core-contracts/lib/go/templates"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsDelegate struct {
+	Signer string `default:"" flag:"signer" info:"Account name from configuration owning the staking collection (required)"`
+}
+
+var delegateFlags = flagsDelegate{}
+
+var delegateCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "delegate <node id> <amount>",
+		Short:   "Register a new delegator to a node and commit tokens",
+		Example: "flow accounts staking delegate 7a1b...e3c 50 --signer alice --network testnet",
+		Args:    cobra.ExactArgs(2),
+	},
+	Flags: &delegateFlags,
+	RunS:  delegate,
+}
+
+func delegate(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	nodeID := args[0]
+	amount, err := parseTokenAmount(args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := stakingSigner(state, delegateFlags.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := stakingEnvironment(signer.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return sendStakingTransaction(
+		flow,
+		logger,
+		globalFlags,
+		signer,
+		"delegate",
+		[]stakingDetail{{"Node ID", nodeID}, {"Amount", amount.String()}},
+		tmpl.GenerateCollectionRegisterDelegator(env),
+		[]cadence.Value{cadence.String(nodeID), amount},
+	)
+}
@@ -0,0 +1,174 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence"
+	tmpl "github.com/onflow/flow-core-contracts/lib/go/templates"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/templates"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsRegisterNode struct {
+	Signer            string   `default:"" flag:"signer" info:"Account name from configuration owning the staking collection (required)"`
+	Role              string   `default:"" flag:"role" info:"Node role: collection, consensus, execution, verification or access"`
+	NetworkingAddress string   `default:"" flag:"networking-address" info:"Networking address of the node"`
+	NetworkingKey     string   `default:"" flag:"networking-key" info:"Networking public key of the node"`
+	StakingKey        string   `default:"" flag:"staking-key" info:"Staking public key of the node"`
+	MachineKeys       []string `default:"" flag:"machine-key" info:"Public keys of the machine account required for collection and consensus nodes"`
+	MachineSigAlgo    string   `default:"ECDSA_P256" flag:"machine-sig-algo" info:"Signature algorithm of the machine account keys"`
+	MachineHashAlgo   string   `default:"SHA3_256" flag:"machine-hash-algo" info:"Hash algorithm of the machine account keys"`
+}
+
+var registerNodeFlags = flagsRegisterNode{}
+
+var registerNodeCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "register-node <node id> <amount>",
+		Short: "Register a new node in the staking collection and commit tokens",
+		Example: `flow accounts staking register-node 7a1b...e3c 135000 --role execution \
+    --networking-address execution.example.com:3569 --networking-key 0x5d2...aa1 --staking-key 0x9f1...0b2 \
+    --signer alice --network testnet`,
+		Args: cobra.ExactArgs(2),
+	},
+	Flags: &registerNodeFlags,
+	RunS:  registerNode,
+}
+
+// nodeRoles maps the node role names to the role values used by the staking contract.
+var nodeRoles = map[string]uint8{
+	"collection":   1,
+	"consensus":    2,
+	"execution":    3,
+	"verification": 4,
+	"access":       5,
+}
+
+func registerNode(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	nodeID := args[0]
+	amount, err := parseTokenAmount(args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	role, ok := nodeRoles[strings.ToLower(registerNodeFlags.Role)]
+	if !ok {
+		return nil, fmt.Errorf("invalid node role %s, must be one of: collection, consensus, execution, verification, access", registerNodeFlags.Role)
+	}
+
+	if registerNodeFlags.NetworkingAddress == "" || registerNodeFlags.NetworkingKey == "" || registerNodeFlags.StakingKey == "" {
+		return nil, fmt.Errorf("networking address, networking key and staking key flags must be provided")
+	}
+
+	machineKeys, err := machineAccountKeys(registerNodeFlags.MachineKeys)
+	if err != nil {
+		return nil, err
+	}
+	if (role == nodeRoles["collection"] || role == nodeRoles["consensus"]) && len(machineKeys) == 0 {
+		return nil, fmt.Errorf("machine account keys must be provided for %s nodes", registerNodeFlags.Role)
+	}
+
+	signer, err := stakingSigner(state, registerNodeFlags.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := stakingEnvironment(signer.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return sendStakingTransaction(
+		flow,
+		logger,
+		globalFlags,
+		signer,
+		"register node",
+		[]stakingDetail{
+			{"Node ID", nodeID},
+			{"Role", strings.ToLower(registerNodeFlags.Role)},
+			{"Networking Address", registerNodeFlags.NetworkingAddress},
+			{"Amount", amount.String()},
+			{"Machine Keys", fmt.Sprintf("%d", len(machineKeys))},
+		},
+		tmpl.GenerateCollectionRegisterNode(env),
+		[]cadence.Value{
+			cadence.String(nodeID),
+			cadence.NewUInt8(role),
+			cadence.String(registerNodeFlags.NetworkingAddress),
+			cadence.String(strings.TrimPrefix(registerNodeFlags.NetworkingKey, "0x")),
+			cadence.String(strings.TrimPrefix(registerNodeFlags.StakingKey, "0x")),
+			amount,
+			cadence.NewOptional(cadence.NewArray(machineKeys)),
+		},
+	)
+}
+
+// machineAccountKeys converts the machine account public keys to Crypto.KeyListEntry values with full weight.
+func machineAccountKeys(publicKeys []string) ([]cadence.Value, error) {
+	sigAlgos, err := parseSignatureAlgorithms([]string{registerNodeFlags.MachineSigAlgo})
+	if err != nil {
+		return nil, err
+	}
+
+	hashAlgos, err := parseHashingAlgorithms([]string{registerNodeFlags.MachineHashAlgo})
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]cadence.Value, 0, len(publicKeys))
+	for _, k := range publicKeys {
+		if k == "" {
+			continue
+		}
+
+		pubKeys, err := parsePublicKeys([]string{k}, sigAlgos)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := templates.AccountKeyToCadenceCryptoKey(&flowsdk.AccountKey{
+			PublicKey: pubKeys[0],
+			SigAlgo:   sigAlgos[0],
+			HashAlgo:  hashAlgos[0],
+			Weight:    flowsdk.AccountKeyWeightThreshold,
+		})
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,129 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"github.com/onflow/cadence"
+	tmpl "github.com/onflow/flow-core-contracts/lib/go/templates"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+// flagsStakingTokens are shared by the actions that move tokens of a node or a delegator in the staking collection.
+type flagsStakingTokens struct {
+	Signer    string `default:"" flag:"signer" info:"Account name from configuration owning the staking collection (required)"`
+	Delegator string `default:"" flag:"delegator" info:"Delegator ID to use instead of the node stake"`
+}
+
+var (
+	stakeFlags           = flagsStakingTokens{}
+	unstakeFlags         = flagsStakingTokens{}
+	withdrawRewardsFlags = flagsStakingTokens{}
+)
+
+var stakeCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "stake <node id> <amount>",
+		Short:   "Stake new tokens for a node or a delegator",
+		Example: "flow accounts staking stake 7a1b...e3c 100 --signer alice --network testnet",
+		Args:    cobra.ExactArgs(2),
+	},
+	Flags: &stakeFlags,
+	RunS:  stakingTokensAction(&stakeFlags, "stake", tmpl.GenerateCollectionStakeNewTokens),
+}
+
+var unstakeCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "unstake <node id> <amount>",
+		Short:   "Request unstaking of tokens for a node or a delegator",
+		Example: "flow accounts staking unstake 7a1b...e3c 100 --delegator 2 --signer alice --network testnet",
+		Args:    cobra.ExactArgs(2),
+	},
+	Flags: &unstakeFlags,
+	RunS:  stakingTokensAction(&unstakeFlags, "unstake", tmpl.GenerateCollectionRequestUnstaking),
+}
+
+var withdrawRewardsCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "withdraw-rewards <node id> <amount>",
+		Short:   "Withdraw rewarded tokens of a node or a delegator",
+		Example: "flow accounts staking withdraw-rewards 7a1b...e3c 10.5 --signer alice --network testnet",
+		Args:    cobra.ExactArgs(2),
+	},
+	Flags: &withdrawRewardsFlags,
+	RunS:  stakingTokensAction(&withdrawRewardsFlags, "withdraw rewards", tmpl.GenerateCollectionWithdrawRewardedTokens),
+}
+
+// stakingTokensAction creates the command run function for the staking collection transaction
+// templates accepting the node ID, an optional delegator ID and the amount of tokens.
+func stakingTokensAction(
+	flags *flagsStakingTokens,
+	action string,
+	template func(tmpl.Environment) []byte,
+) command.RunWithState {
+	return func(
+		args []string,
+		globalFlags command.GlobalFlags,
+		logger output.Logger,
+		flow flowkit.Services,
+		state *flowkit.State,
+	) (command.Result, error) {
+		nodeID := args[0]
+		amount, err := parseTokenAmount(args[1])
+		if err != nil {
+			return nil, err
+		}
+
+		delegatorID, err := parseDelegatorID(flags.Delegator)
+		if err != nil {
+			return nil, err
+		}
+
+		signer, err := stakingSigner(state, flags.Signer)
+		if err != nil {
+			return nil, err
+		}
+
+		env, err := stakingEnvironment(signer.Address)
+		if err != nil {
+			return nil, err
+		}
+
+		details := []stakingDetail{{"Node ID", nodeID}}
+		if flags.Delegator != "" {
+			details = append(details, stakingDetail{"Delegator ID", flags.Delegator})
+		}
+		details = append(details, stakingDetail{"Amount", amount.String()})
+
+		return sendStakingTransaction(
+			flow,
+			logger,
+			globalFlags,
+			signer,
+			action,
+			details,
+			template(env),
+			[]cadence.Value{cadence.String(nodeID), delegatorID, amount},
+		)
+	}
+}
@@ -0,0 +1,196 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/onflow/cadence"
+	tmpl "github.com/onflow/flow-core-contracts/lib/go/templates"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/accounts"
+	"github.com/onflow/flowkit/output"
+	"github.com/onflow/flowkit/transactions"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/prompt"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+var stakingCmd = &cobra.Command{
+	Use:              "staking <stake|unstake|withdraw-rewards|delegate|register-node>",
+	Short:            "Manage staked and delegated tokens of an account staking collection",
+	Example:          "flow accounts staking stake 7a1b...e3c 100 --signer alice --network testnet",
+	Args:             cobra.ExactArgs(1),
+	TraverseChildren: true,
+}
+
+func init() {
+	stakeCommand.AddToParent(stakingCmd)
+	unstakeCommand.AddToParent(stakingCmd)
+	withdrawRewardsCommand.AddToParent(stakingCmd)
+	delegateCommand.AddToParent(stakingCmd)
+	registerNodeCommand.AddToParent(stakingCmd)
+}
+
+// stakingDetail is a labeled value shown in the preview of a staking transaction and in the result.
+type stakingDetail struct {
+	label string
+	value string
+}
+
+// stakingSigner returns the account owning the staking collection, the account must always be provided
+// since staking actions move real tokens.
+func stakingSigner(state *flowkit.State, name string) (*accounts.Account, error) {
+	if name == "" {
+		return nil, fmt.Errorf("the account owning the staking collection must be provided with the --signer flag")
+	}
+
+	return state.Accounts().ByName(name)
+}
+
+// stakingEnvironment returns the staking contract addresses for the chain the signer address belongs to.
+func stakingEnvironment(address flowsdk.Address) (tmpl.Environment, error) {
+	chain, err := util.GetAddressNetwork(address)
+	if err != nil {
+		return tmpl.Environment{}, fmt.Errorf("failed to determine network from address, check the address and network")
+	}
+
+	if chain == flowsdk.Emulator {
+		return tmpl.Environment{}, fmt.Errorf("emulator chain not supported")
+	}
+
+	return envFromNetwork(chain), nil
+}
+
+// parseDelegatorID parses the optional delegator ID, an empty value means the node itself is used.
+func parseDelegatorID(value string) (cadence.Value, error) {
+	if value == "" {
+		return cadence.NewOptional(nil), nil
+	}
+
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid delegator ID %s, must be a positive number", value)
+	}
+
+	return cadence.NewOptional(cadence.NewUInt32(uint32(id))), nil
+}
+
+// sendStakingTransaction shows a preview of the staking action and sends the transaction
+// signed by the signer account once the user approves it.
+func sendStakingTransaction(
+	flow flowkit.Services,
+	logger output.Logger,
+	globalFlags command.GlobalFlags,
+	signer *accounts.Account,
+	action string,
+	details []stakingDetail,
+	code []byte,
+	args []cadence.Value,
+) (command.Result, error) {
+	result := &stakingActionResult{
+		action:  action,
+		signer:  signer.Address,
+		details: details,
+	}
+
+	var preview bytes.Buffer
+	writer := util.CreateTabWriter(&preview)
+	_, _ = fmt.Fprintf(writer, "Preview of %s transaction\n\n", action)
+	result.writeDetails(writer)
+	_ = writer.Flush()
+
+	logger.Info(preview.String())
+	if !globalFlags.Yes && !prompt.GenericBoolPrompt("Do you want to send the staking transaction?") {
+		return nil, nil
+	}
+
+	logger.StartProgress(fmt.Sprintf("Sending %s transaction...", action))
+	defer logger.StopProgress()
+
+	tx, txResult, err := flow.SendTransaction(
+		context.Background(),
+		transactions.SingleAccountRole(*signer),
+		flowkit.Script{Code: code, Args: args},
+		flowsdk.DefaultTransactionGasLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if txResult.Error != nil {
+		return nil, fmt.Errorf("%s transaction %s failed: %w", action, tx.ID(), txResult.Error)
+	}
+
+	result.id = tx.ID()
+	return result, nil
+}
+
+type stakingActionResult struct {
+	id      flowsdk.Identifier
+	action  string
+	signer  flowsdk.Address
+	details []stakingDetail
+}
+
+// writeDetails writes the signer and the action details as tab separated rows.
+func (r *stakingActionResult) writeDetails(writer io.Writer) {
+	_, _ = fmt.Fprintf(writer, "Signer\t 0x%s\n", r.signer)
+	for _, detail := range r.details {
+		_, _ = fmt.Fprintf(writer, "%s\t %s\n", detail.label, detail.value)
+	}
+}
+
+func (r *stakingActionResult) JSON() any {
+	result := make(map[string]any)
+	result["id"] = r.id.String()
+	result["action"] = r.action
+	result["signer"] = fmt.Sprintf("0x%s", r.signer)
+
+	details := make(map[string]string)
+	for _, detail := range r.details {
+		details[detail.label] = detail.value
+	}
+	result["details"] = details
+
+	return result
+}
+
+func (r *stakingActionResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "%s Sent %s transaction\n\n", output.SuccessEmoji(), r.action)
+	_, _ = fmt.Fprintf(writer, "ID\t %s\n", r.id)
+	r.writeDetails(writer)
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *stakingActionResult) Oneliner() string {
+	return fmt.Sprintf("ID: %s, Action: %s, Signer: 0x%s", r.id, r.action, r.signer)
+}
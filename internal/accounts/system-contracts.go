@@ -0,0 +1,90 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go/fvm/systemcontracts"
+	flowGo "github.com/onflow/flow-go/model/flow"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/config"
+
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// systemContractsForNetwork returns the core contracts deployed on the chain of the provided network.
+//
+// The chain is resolved from the default network names and falls back to the chain the provided address belongs to.
+func systemContractsForNetwork(network config.Network, address flowsdk.Address) (*systemcontracts.SystemContracts, error) {
+	chains := map[string]flowGo.ChainID{
+		config.EmulatorNetwork.Name: flowGo.Emulator,
+		config.TestnetNetwork.Name:  flowGo.Testnet,
+		config.MainnetNetwork.Name:  flowGo.Mainnet,
+	}
+
+	chain, ok := chains[network.Name]
+	if !ok {
+		addressChain, err := util.GetAddressNetwork(address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine the chain for network %s: %w", network.Name, err)
+		}
+		chain = flowGo.ChainID(addressChain)
+	}
+
+	return systemcontracts.SystemContractsForChain(chain), nil
+}
+
+// resolveContractAddress returns the address of the contract on the provided network.
+//
+// The contract can be referenced using the address directly (0x01.Token or A.01.Token), by the name
+// of a contract aliased or deployed on the network in the configuration, or by the name of a core contract.
+func resolveContractAddress(
+	state *flowkit.State,
+	network config.Network,
+	sc *systemcontracts.SystemContracts,
+	contract string,
+) (string, flowsdk.Address, error) {
+	if address, name, found := strings.Cut(strings.TrimPrefix(contract, "A."), "."); found {
+		return name, flowsdk.HexToAddress(address), nil
+	}
+
+	if state != nil {
+		if c, err := state.Contracts().ByName(contract); err == nil {
+			if alias := c.Aliases.ByNetwork(network.Name); alias != nil {
+				return contract, alias.Address, nil
+			}
+			if address, err := state.ContractAddress(c, network); err == nil {
+				return contract, *address, nil
+			}
+		}
+	}
+
+	for _, coreContract := range sc.All() {
+		if coreContract.Name == contract {
+			return contract, flowsdk.HexToAddress(coreContract.Address.String()), nil
+		}
+	}
+
+	return "", flowsdk.EmptyAddress, fmt.Errorf("contract %s is not a core contract and is not aliased or deployed on network %s", contract, network.Name)
+}
@@ -0,0 +1,378 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/accounts"
+	"github.com/onflow/flowkit/output"
+	"github.com/onflow/flowkit/transactions"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsTransfer struct {
+	Signer       string `default:"emulator-account" flag:"signer" info:"Account name from configuration used to sign the transaction and send the tokens"`
+	Token        string `default:"FlowToken" flag:"token" info:"Fungible token contract name from configuration or address qualified name (0x01.Token)"`
+	VaultPath    string `default:"" flag:"vault-path" info:"Storage path identifier of the token vault, defaults to flowTokenVault for FlowToken"`
+	ReceiverPath string `default:"" flag:"receiver-path" info:"Public path identifier of the token receiver, defaults to flowTokenReceiver for FlowToken"`
+}
+
+var transferFlags = flagsTransfer{}
+
+var transferCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "transfer <amount> <address|account name>",
+		Short: "Transfer fungible tokens to an account",
+		Example: `flow accounts transfer 10.5 0x01cf0e2f2f715450 --signer alice
+
+flow accounts transfer 100 bob --token ExampleToken --vault-path exampleTokenVault --receiver-path exampleTokenReceiver`,
+		Args: cobra.ExactArgs(2),
+	},
+	Flags: &transferFlags,
+	RunS:  transfer,
+}
+
+const transferTokensTransaction = `
+import FungibleToken from 0x%s
+
+transaction(amount: UFix64, to: Address, vaultPath: String, receiverPath: String, vaultType: String) {
+    let sentVault: @FungibleToken.Vault
+
+    prepare(signer: AuthAccount) {
+        let path = StoragePath(identifier: vaultPath) ?? panic("invalid vault path")
+        let vault = signer.borrow<&{FungibleToken.Provider}>(from: path)
+            ?? panic("could not borrow the token vault of the signer")
+
+        self.sentVault <- vault.withdraw(amount: amount)
+        assert(self.sentVault.getType().identifier == vaultType, message: "vault is not of the requested token type")
+    }
+
+    execute {
+        let path = PublicPath(identifier: receiverPath) ?? panic("invalid receiver path")
+        let receiver = getAccount(to).getCapability(path).borrow<&{FungibleToken.Receiver}>()
+            ?? panic("could not borrow the token receiver of the recipient")
+
+        receiver.deposit(from: <-self.sentVault)
+    }
+}
+`
+
+const tokenBalanceScript = `
+import FungibleToken from 0x%s
+
+pub fun main(address: Address, vaultPath: String): UFix64? {
+    let path = StoragePath(identifier: vaultPath) ?? panic("invalid vault path")
+    return getAuthAccount(address).borrow<&{FungibleToken.Balance}>(from: path)?.balance
+}
+`
+
+const tokenReceiverScript = `
+import FungibleToken from 0x%s
+
+pub fun main(address: Address, receiverPath: String): Bool {
+    let path = PublicPath(identifier: receiverPath) ?? panic("invalid receiver path")
+    return getAccount(address).getCapability<&{FungibleToken.Receiver}>(path).check()
+}
+`
+
+// fungibleToken contains all the information needed to interact with a fungible token contract.
+type fungibleToken struct {
+	name                 string
+	address              flowsdk.Address
+	fungibleTokenAddress flowsdk.Address
+	vaultPath            string
+	receiverPath         string
+}
+
+func (t *fungibleToken) vaultType() string {
+	return fmt.Sprintf("A.%s.%s.Vault", t.address, t.name)
+}
+
+func (t *fungibleToken) balance(flow flowkit.Services, address flowsdk.Address) (*cadence.UFix64, error) {
+	value, err := flow.ExecuteScript(
+		context.Background(),
+		flowkit.Script{
+			Code: []byte(fmt.Sprintf(tokenBalanceScript, t.fungibleTokenAddress)),
+			Args: []cadence.Value{cadence.NewAddress(address), cadence.String(t.vaultPath)},
+		},
+		flowkit.LatestScriptQuery,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s balance of 0x%s: %w", t.name, address, err)
+	}
+
+	if optional, ok := value.(cadence.Optional); ok {
+		value = optional.Value
+	}
+	balance, ok := value.(cadence.UFix64)
+	if !ok {
+		return nil, nil
+	}
+
+	return &balance, nil
+}
+
+func (t *fungibleToken) hasReceiver(flow flowkit.Services, address flowsdk.Address) (bool, error) {
+	value, err := flow.ExecuteScript(
+		context.Background(),
+		flowkit.Script{
+			Code: []byte(fmt.Sprintf(tokenReceiverScript, t.fungibleTokenAddress)),
+			Args: []cadence.Value{cadence.NewAddress(address), cadence.String(t.receiverPath)},
+		},
+		flowkit.LatestScriptQuery,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to check %s receiver of 0x%s: %w", t.name, address, err)
+	}
+
+	return value == cadence.NewBool(true), nil
+}
+
+// resolveFungibleToken finds the token contract and the fungible token standard addresses on the network.
+func resolveFungibleToken(
+	flow flowkit.Services,
+	state *flowkit.State,
+	signer flowsdk.Address,
+	token string,
+	vaultPath string,
+	receiverPath string,
+) (*fungibleToken, error) {
+	sc, err := systemContractsForNetwork(flow.Network(), signer)
+	if err != nil {
+		return nil, err
+	}
+
+	name, address, err := resolveContractAddress(state, flow.Network(), sc, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == sc.FlowToken.Name && address.String() == sc.FlowToken.Address.String() {
+		if vaultPath == "" {
+			vaultPath = "flowTokenVault"
+		}
+		if receiverPath == "" {
+			receiverPath = "flowTokenReceiver"
+		}
+	}
+
+	if vaultPath == "" || receiverPath == "" {
+		return nil, fmt.Errorf("vault path and receiver path flags must be provided for token %s", name)
+	}
+
+	return &fungibleToken{
+		name:                 name,
+		address:              address,
+		fungibleTokenAddress: flowsdk.HexToAddress(sc.FungibleToken.Address.String()),
+		vaultPath:            vaultPath,
+		receiverPath:         receiverPath,
+	}, nil
+}
+
+// parseTokenAmount parses the amount as UFix64 allowing whole numbers to be provided without the decimal point.
+func parseTokenAmount(value string) (cadence.UFix64, error) {
+	if !strings.Contains(value, ".") {
+		value = fmt.Sprintf("%s.0", value)
+	}
+
+	amount, err := cadence.NewUFix64(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %s: %w", value, err)
+	}
+
+	return amount, nil
+}
+
+// resolveAddress returns the address of the account with the provided name in the configuration
+// or parses the value as an address if no such account exists.
+func resolveAddress(state *flowkit.State, value string) (flowsdk.Address, error) {
+	if state != nil {
+		if account, err := state.Accounts().ByName(value); err == nil {
+			return account.Address, nil
+		}
+	}
+
+	address := flowsdk.HexToAddress(value)
+	if address == flowsdk.EmptyAddress {
+		return flowsdk.EmptyAddress, fmt.Errorf("invalid address or account name: %s", value)
+	}
+
+	return address, nil
+}
+
+func transfer(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	amount, err := parseTokenAmount(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := resolveAddress(state, args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := state.Accounts().ByName(transferFlags.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := resolveFungibleToken(
+		flow,
+		state,
+		signer.Address,
+		transferFlags.Token,
+		transferFlags.VaultPath,
+		transferFlags.ReceiverPath,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return sendTokens(flow, logger, signer, token, amount, to)
+}
+
+// sendTokens checks the signer has enough tokens and the recipient can receive them, and then transfers the tokens.
+func sendTokens(
+	flow flowkit.Services,
+	logger output.Logger,
+	signer *accounts.Account,
+	token *fungibleToken,
+	amount cadence.UFix64,
+	to flowsdk.Address,
+) (*transferResult, error) {
+	logger.StartProgress(fmt.Sprintf("Checking %s balance and receiver...", token.name))
+	defer logger.StopProgress()
+
+	balance, err := token.balance(flow, signer.Address)
+	if err != nil {
+		return nil, err
+	}
+	if balance == nil {
+		return nil, fmt.Errorf("account 0x%s does not have a %s vault stored at /storage/%s", signer.Address, token.name, token.vaultPath)
+	}
+	if *balance < amount {
+		return nil, fmt.Errorf("insufficient balance, account 0x%s has %s %s but %s is required", signer.Address, *balance, token.name, amount)
+	}
+
+	ok, err := token.hasReceiver(flow, to)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("account 0x%s does not have a %s receiver at /public/%s", to, token.name, token.receiverPath)
+	}
+
+	logger.StopProgress()
+
+	tx, result, err := flow.SendTransaction(
+		context.Background(),
+		transactions.SingleAccountRole(*signer),
+		flowkit.Script{
+			Code: []byte(fmt.Sprintf(transferTokensTransaction, token.fungibleTokenAddress)),
+			Args: []cadence.Value{
+				amount,
+				cadence.NewAddress(to),
+				cadence.String(token.vaultPath),
+				cadence.String(token.receiverPath),
+				cadence.String(token.vaultType()),
+			},
+		},
+		flowsdk.DefaultTransactionGasLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("transfer transaction %s failed: %w", tx.ID(), result.Error)
+	}
+
+	remaining, err := token.balance(flow, signer.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transferResult{
+		id:        tx.ID(),
+		token:     token.name,
+		amount:    amount,
+		from:      signer.Address,
+		to:        to,
+		remaining: remaining,
+	}, nil
+}
+
+type transferResult struct {
+	id        flowsdk.Identifier
+	token     string
+	amount    cadence.UFix64
+	from      flowsdk.Address
+	to        flowsdk.Address
+	remaining *cadence.UFix64
+}
+
+func (r *transferResult) JSON() any {
+	result := make(map[string]any)
+	result["id"] = r.id.String()
+	result["token"] = r.token
+	result["amount"] = r.amount.String()
+	result["from"] = fmt.Sprintf("0x%s", r.from)
+	result["to"] = fmt.Sprintf("0x%s", r.to)
+	if r.remaining != nil {
+		result["balance"] = r.remaining.String()
+	}
+
+	return result
+}
+
+func (r *transferResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "%s Transferred %s %s\n\n", output.SuccessEmoji(), r.amount, r.token)
+	_, _ = fmt.Fprintf(writer, "ID\t %s\n", r.id)
+	_, _ = fmt.Fprintf(writer, "From\t 0x%s\n", r.from)
+	_, _ = fmt.Fprintf(writer, "To\t 0x%s\n", r.to)
+	if r.remaining != nil {
+		_, _ = fmt.Fprintf(writer, "Sender Balance\t %s\n", r.remaining)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *transferResult) Oneliner() string {
+	return fmt.Sprintf("ID: %s, Token: %s, Amount: %s, From: 0x%s, To: 0x%s", r.id, r.token, r.amount, r.from, r.to)
+}
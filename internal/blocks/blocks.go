@@ -27,6 +27,7 @@ import (
 
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/events"
+	"github.com/onflow/flow-cli/internal/transactions"
 	"github.com/onflow/flow-cli/internal/util"
 )
 
@@ -39,12 +40,15 @@ var Cmd = &cobra.Command{
 
 func init() {
 	getCommand.AddToParent(Cmd)
+	rangeCommand.AddToParent(Cmd)
+	statsCommand.AddToParent(Cmd)
 }
 
 type blockResult struct {
 	block       *flow.Block
 	events      []flow.BlockEvents
 	collections []*flow.Collection
+	results     transactions.Results
 	included    []string
 }
 
@@ -73,6 +77,14 @@ func (r *blockResult) JSON() any {
 	}
 
 	result["collection"] = collections
+
+	if command.ContainsFlag(r.included, "transaction-results") {
+		result["transactionResults"] = r.results.TableJSON()
+	}
+	if command.ContainsFlag(r.included, "events") {
+		result["transactionEvents"] = r.results.EventsJSON()
+	}
+
 	return result
 }
 
@@ -112,6 +124,16 @@ func (r *blockResult) String() string {
 		}
 	}
 
+	if command.ContainsFlag(r.included, "transaction-results") {
+		_, _ = fmt.Fprintf(writer, "\nTransactions\t%d\n", len(r.results))
+		r.results.WriteTable(writer)
+	}
+
+	if command.ContainsFlag(r.included, "events") {
+		_, _ = fmt.Fprintf(writer, "\n")
+		r.results.WriteEvents(writer)
+	}
+
 	if len(r.events) > 0 {
 		_, _ = fmt.Fprintf(writer, "\n")
 
@@ -19,12 +19,16 @@
 package blocks
 
 import (
+	"context"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/onflow/flow-go-sdk"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flowkit"
 	"github.com/onflow/flowkit/tests"
@@ -60,6 +64,42 @@ func Test_GetBlock(t *testing.T) {
 		assert.NotNil(t, result)
 		assert.NoError(t, err)
 	})
+
+	t.Run("Success include transaction results and events", func(t *testing.T) {
+		srv, _, rw := util.TestMocks(t)
+		blockFlags.Events = ""
+		blockFlags.Include = []string{"transaction-results", "events"}
+		defer func() { blockFlags.Include = nil }()
+
+		block := tests.NewBlock()
+		srv.GetBlock.Return(block, nil)
+
+		event := tests.NewEvent(0, "A.foo", nil, nil)
+		succeeded := tests.NewTransactionResult([]flow.Event{*event})
+		failed := tests.NewTransactionResult([]flow.Event{*event})
+		failed.Error = fmt.Errorf("execution failed\nwith details")
+
+		txs := make([]*flow.Transaction, 0)
+		for i := byte(0); i < 3; i++ {
+			txs = append(txs, flow.NewTransaction().SetScript([]byte{i}).SetPayer(flow.HexToAddress("0x01")))
+		}
+		// the last transaction is the system chunk transaction
+		srv.GetTransactionsByBlockID.Run(func(args mock.Arguments) {
+			assert.Equal(t, block.ID, args.Get(1).(flow.Identifier))
+		}).Return(txs, []*flow.TransactionResult{succeeded, failed, succeeded}, nil)
+
+		result, err := get([]string{"latest"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.NoError(t, err)
+
+		r := result.(*blockResult)
+		assert.Len(t, r.results, 3)
+		assert.Equal(t, txs[2].ID(), r.results[2].Transaction.ID())
+		assert.Empty(t, r.collections)
+		assert.Contains(t, r.String(), "execution failed")
+		assert.NotContains(t, r.String(), "with details")
+		assert.Contains(t, r.String(), "Events Transaction")
+		assert.Len(t, r.JSON().(map[string]any)["transactionEvents"], 3)
+	})
 }
 
 func Test_Result(t *testing.T) {
@@ -99,3 +139,108 @@ Total Collections	3
 		result.JSON(),
 	)
 }
+
+func Test_Range(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+	base := time.Unix(1700000000, 0)
+
+	newBlock := func(height uint64) *flow.Block {
+		block := &flow.Block{}
+		block.ID = flow.Identifier{byte(height)}
+		block.Height = height
+		block.Timestamp = base.Add(time.Duration(height) * 2 * time.Second)
+		block.CollectionGuarantees = []*flow.CollectionGuarantee{{CollectionID: flow.Identifier{byte(height), 1}}}
+		if height >= 2 {
+			block.Seals = []*flow.BlockSeal{{BlockID: flow.Identifier{byte(height - 2)}}}
+		}
+		return block
+	}
+
+	srv.GetBlock.Return(func(_ context.Context, query flowkit.BlockQuery) (*flow.Block, error) {
+		switch {
+		case query.Latest:
+			return newBlock(5), nil
+		case query.ID != nil:
+			return newBlock(uint64(query.ID[0])), nil
+		default:
+			return newBlock(query.Height), nil
+		}
+	})
+	srv.GetCollection.Return(&flow.Collection{TransactionIDs: []flow.Identifier{{1}, {2}}}, nil)
+
+	rangeStartChanged, rangeEndChanged = true, true
+	defer func() { rangeStartChanged, rangeEndChanged = false, false }()
+
+	t.Run("Success range", func(t *testing.T) {
+		rangeFlags.Start = 2
+		rangeFlags.End = 5
+		rangeFlags.Workers = 3
+
+		result, err := blockRange(nil, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.NoError(t, err)
+
+		r := result.(*rangeResult)
+		assert.Len(t, r.blocks, 4)
+		for i, block := range r.blocks {
+			assert.Equal(t, uint64(2+i), block.height)
+			assert.Equal(t, 2, block.transactions)
+			assert.Equal(t, uint64(2), *block.sealLag)
+		}
+		assert.Equal(t, 8, r.stats.transactions)
+		assert.Equal(t, 1.0, r.stats.tps)
+		assert.Equal(t, 2.0, r.stats.interval.p50)
+		assert.Equal(t, 1.0, r.stats.blockTPS.p99)
+	})
+
+	t.Run("Success stats", func(t *testing.T) {
+		statsFlags.Last = 3
+		statsFlags.Workers = 2
+
+		result, err := stats(nil, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.NoError(t, err)
+
+		s := result.(*blockStats)
+		assert.Equal(t, uint64(3), s.start)
+		assert.Equal(t, uint64(5), s.end)
+		assert.Equal(t, 3, s.blocks)
+		assert.Contains(t, s.String(), "Throughput")
+	})
+
+	t.Run("Success range from genesis", func(t *testing.T) {
+		rangeFlags.Start = 0
+		rangeFlags.End = 1
+
+		result, err := blockRange(nil, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.NoError(t, err)
+
+		r := result.(*rangeResult)
+		require.Len(t, r.blocks, 2)
+		assert.Equal(t, uint64(0), r.blocks[0].height)
+	})
+
+	t.Run("Fail invalid range", func(t *testing.T) {
+		rangeFlags.Start = 5
+		rangeFlags.End = 2
+
+		_, err := blockRange(nil, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "start height 5 must not be greater than end height 2")
+	})
+
+	t.Run("Fail range too large", func(t *testing.T) {
+		rangeFlags.Start = 1
+		rangeFlags.End = maxRangeBlocks + 1
+
+		_, err := blockRange(nil, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "range of 10001 blocks exceeds the maximum of 10000 blocks, split it into smaller ranges")
+	})
+}
+
+func Test_Percentile(t *testing.T) {
+	d := newDistribution([]float64{5, 1, 4, 2, 3, 6, 7, 8, 9, 10})
+	assert.Equal(t, 1.0, d.min)
+	assert.Equal(t, 5.5, d.avg)
+	assert.Equal(t, 5.0, d.p50)
+	assert.Equal(t, 9.0, d.p90)
+	assert.Equal(t, 10.0, d.p99)
+	assert.Equal(t, 10.0, d.max)
+}
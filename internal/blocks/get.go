@@ -28,21 +28,24 @@ import (
 	"github.com/onflow/flowkit/output"
 
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/transactions"
 )
 
 type flagsBlocks struct {
 	Events  string   `default:"" flag:"events" info:"List events of this type for the block"`
-	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: transactions."`
+	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: transactions, transaction-results, events."`
 }
 
 var blockFlags = flagsBlocks{}
 
 var getCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "get <block_id|latest|block_height>",
-		Short:   "Get block info",
-		Example: "flow blocks get latest --network testnet",
-		Args:    cobra.ExactArgs(1),
+		Use:   "get <block_id|latest|block_height>",
+		Short: "Get block info",
+		Example: `flow blocks get latest --network testnet
+
+flow blocks get 11559500 --include transaction-results,events --network mainnet`,
+		Args: cobra.ExactArgs(1),
 	},
 	Flags: &blockFlags,
 	Run:   get,
@@ -93,10 +96,26 @@ func get(
 		}
 	}
 
+	// the block transactions include the system chunk transaction which is not part of any collection
+	var results transactions.Results
+	if command.ContainsFlag(blockFlags.Include, "transaction-results") ||
+		command.ContainsFlag(blockFlags.Include, "events") {
+		txs, txResults, err := flow.GetTransactionsByBlockID(context.Background(), block.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		results, err = transactions.NewResults(txs, txResults)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &blockResult{
 		block:       block,
 		events:      events,
 		collections: collections,
+		results:     results,
 		included:    blockFlags.Include,
 	}, nil
 }
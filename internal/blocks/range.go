@@ -0,0 +1,281 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package blocks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsRange struct {
+	Start   uint64 `flag:"start" info:"Start block height"`
+	End     uint64 `flag:"end" info:"End block height"`
+	Workers int    `default:"10" flag:"workers" info:"Number of workers to use when fetching blocks in parallel"`
+}
+
+var rangeFlags = flagsRange{}
+
+// rangeStartChanged and rangeEndChanged are set when the range flags are provided, since 0 is a valid height.
+var rangeStartChanged, rangeEndChanged bool
+
+// maxRangeBlocks limits the number of blocks fetched for a range, since all the blocks are kept in memory.
+const maxRangeBlocks = 10000
+
+var rangeCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "range",
+		Short:   "Get block info and statistics for a block range",
+		Example: "flow blocks range --start 11559500 --end 11559600 --network testnet",
+		Args:    cobra.NoArgs,
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			rangeStartChanged = cmd.Flags().Changed("start")
+			rangeEndChanged = cmd.Flags().Changed("end")
+		},
+	},
+	Flags: &rangeFlags,
+	Run:   blockRange,
+}
+
+func blockRange(
+	_ []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	if !rangeStartChanged || !rangeEndChanged {
+		return nil, fmt.Errorf("please provide both start and end heights for the range")
+	}
+	if rangeFlags.Start > rangeFlags.End {
+		return nil, fmt.Errorf("start height %d must not be greater than end height %d", rangeFlags.Start, rangeFlags.End)
+	}
+
+	logger.StartProgress(fmt.Sprintf("Fetching blocks %d to %d...", rangeFlags.Start, rangeFlags.End))
+	defer logger.StopProgress()
+
+	blocks, err := fetchBlockRange(flow, rangeFlags.Start, rangeFlags.End, rangeFlags.Workers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rangeResult{
+		blocks: blocks,
+		stats:  newBlockStats(blocks),
+	}, nil
+}
+
+// blockInfo contains the block details used for the range statistics.
+type blockInfo struct {
+	id           flowsdk.Identifier
+	height       uint64
+	timestamp    time.Time
+	collections  int
+	transactions int
+	// interval is the time since the previous block, zero for the first block in the range.
+	interval time.Duration
+	// sealLag is the number of blocks between the block and the highest block it seals, nil if it contains no seals.
+	sealLag *uint64
+	seals   []flowsdk.Identifier
+}
+
+// fetchBlockRange fetches the blocks and their collections in the range using a pool of workers
+// and returns the blocks ordered by height.
+func fetchBlockRange(flow flowkit.Services, start uint64, end uint64, workers int) ([]blockInfo, error) {
+	if workers <= 0 {
+		return nil, fmt.Errorf("workers must be a positive number")
+	}
+	if end-start >= maxRangeBlocks {
+		return nil, fmt.Errorf(
+			"range of %d blocks exceeds the maximum of %d blocks, split it into smaller ranges",
+			end-start+1,
+			maxRangeBlocks,
+		)
+	}
+
+	blocks := make([]blockInfo, end-start+1)
+	err := util.RunWorkers(len(blocks), workers, func(i int) error {
+		info, err := fetchBlockInfo(flow, start+uint64(i))
+		if err != nil {
+			return err
+		}
+		blocks[i] = *info
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 1; i < len(blocks); i++ {
+		blocks[i].interval = blocks[i].timestamp.Sub(blocks[i-1].timestamp)
+	}
+
+	err = resolveSealLag(flow, blocks, workers)
+	if err != nil {
+		return nil, err
+	}
+
+	return blocks, nil
+}
+
+func fetchBlockInfo(flow flowkit.Services, height uint64) (*blockInfo, error) {
+	block, err := flow.GetBlock(context.Background(), flowkit.BlockQuery{Height: height})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %d: %w", height, err)
+	}
+
+	info := &blockInfo{
+		id:          block.ID,
+		height:      block.Height,
+		timestamp:   block.Timestamp,
+		collections: len(block.CollectionGuarantees),
+	}
+
+	for _, guarantee := range block.CollectionGuarantees {
+		collection, err := flow.GetCollection(context.Background(), guarantee.CollectionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get collection %s: %w", guarantee.CollectionID, err)
+		}
+		info.transactions += len(collection.TransactionIDs)
+	}
+
+	for _, seal := range block.Seals {
+		info.seals = append(info.seals, seal.BlockID)
+	}
+
+	return info, nil
+}
+
+// resolveSealLag calculates the seal lag of the blocks, the heights of sealed blocks outside the range
+// are fetched using a pool of workers.
+func resolveSealLag(flow flowkit.Services, blocks []blockInfo, workers int) error {
+	heights := make(map[flowsdk.Identifier]uint64)
+	for _, block := range blocks {
+		heights[block.id] = block.height
+	}
+
+	missing := make([]flowsdk.Identifier, 0)
+	for _, block := range blocks {
+		for _, id := range block.seals {
+			if _, ok := heights[id]; !ok {
+				heights[id] = 0
+				missing = append(missing, id)
+			}
+		}
+	}
+
+	sealedHeights := make([]uint64, len(missing))
+	err := util.RunWorkers(len(missing), workers, func(i int) error {
+		b, err := flow.GetBlock(context.Background(), flowkit.BlockQuery{ID: &missing[i]})
+		if err != nil {
+			return fmt.Errorf("failed to get sealed block %s: %w", missing[i], err)
+		}
+		sealedHeights[i] = b.Height
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for i, id := range missing {
+		heights[id] = sealedHeights[i]
+	}
+
+	for i, block := range blocks {
+		for _, id := range block.seals {
+			sealed := heights[id]
+			if sealed > block.height {
+				continue
+			}
+			lag := block.height - sealed
+			if blocks[i].sealLag == nil || lag < *blocks[i].sealLag {
+				blocks[i].sealLag = &lag
+			}
+		}
+	}
+
+	return nil
+}
+
+type rangeResult struct {
+	blocks []blockInfo
+	stats  *blockStats
+}
+
+func (r *rangeResult) JSON() any {
+	blocks := make([]any, 0, len(r.blocks))
+	for _, block := range r.blocks {
+		result := map[string]any{
+			"id":           block.id.String(),
+			"height":       block.height,
+			"timestamp":    block.timestamp,
+			"interval":     block.interval.Seconds(),
+			"collections":  block.collections,
+			"transactions": block.transactions,
+		}
+		if block.sealLag != nil {
+			result["sealLag"] = *block.sealLag
+		}
+		blocks = append(blocks, result)
+	}
+
+	return map[string]any{
+		"blocks": blocks,
+		"stats":  r.stats.JSON(),
+	}
+}
+
+func (r *rangeResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Height\tTime\tInterval\tCollections\tTransactions\tSeal Lag\n")
+	for _, block := range r.blocks {
+		sealLag := "-"
+		if block.sealLag != nil {
+			sealLag = fmt.Sprintf("%d", *block.sealLag)
+		}
+		_, _ = fmt.Fprintf(
+			writer,
+			"%d\t%s\t%s\t%d\t%d\t%s\n",
+			block.height,
+			block.timestamp.UTC().Format(time.RFC3339),
+			block.interval,
+			block.collections,
+			block.transactions,
+			sealLag,
+		)
+	}
+
+	_ = writer.Flush()
+	return fmt.Sprintf("%s\n%s", b.String(), r.stats.String())
+}
+
+func (r *rangeResult) Oneliner() string {
+	return r.stats.Oneliner()
+}
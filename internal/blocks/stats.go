@@ -0,0 +1,241 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package blocks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsStats struct {
+	Last    uint64 `default:"500" flag:"last" info:"Number of blocks relative to the last block to include in the statistics"`
+	Workers int    `default:"10" flag:"workers" info:"Number of workers to use when fetching blocks in parallel"`
+}
+
+var statsFlags = flagsStats{}
+
+var statsCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "stats",
+		Short:   "Get chain statistics for the latest blocks",
+		Example: "flow blocks stats --last 500 --network testnet",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &statsFlags,
+	Run:   stats,
+}
+
+func stats(
+	_ []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	if statsFlags.Last == 0 {
+		return nil, fmt.Errorf("last must be a positive number")
+	}
+
+	latest, err := flow.GetBlock(context.Background(), flowkit.BlockQuery{Latest: true})
+	if err != nil {
+		return nil, err
+	}
+
+	start := uint64(0)
+	if latest.Height >= statsFlags.Last {
+		start = latest.Height - statsFlags.Last + 1
+	}
+
+	logger.StartProgress(fmt.Sprintf("Fetching blocks %d to %d...", start, latest.Height))
+	defer logger.StopProgress()
+
+	blocks, err := fetchBlockRange(flow, start, latest.Height, statsFlags.Workers)
+	if err != nil {
+		return nil, err
+	}
+
+	return newBlockStats(blocks), nil
+}
+
+// distribution summarizes the values with the average and percentiles.
+type distribution struct {
+	min float64
+	avg float64
+	p50 float64
+	p90 float64
+	p99 float64
+	max float64
+}
+
+func newDistribution(values []float64) distribution {
+	if len(values) == 0 {
+		return distribution{}
+	}
+
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, v := range sorted {
+		sum += v
+	}
+
+	return distribution{
+		min: sorted[0],
+		avg: sum / float64(len(sorted)),
+		p50: percentile(sorted, 50),
+		p90: percentile(sorted, 90),
+		p99: percentile(sorted, 99),
+		max: sorted[len(sorted)-1],
+	}
+}
+
+// percentile returns the nearest-rank percentile of the sorted values.
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
+
+func (d distribution) JSON() any {
+	return map[string]any{
+		"min": d.min,
+		"avg": d.avg,
+		"p50": d.p50,
+		"p90": d.p90,
+		"p99": d.p99,
+		"max": d.max,
+	}
+}
+
+func (d distribution) String() string {
+	return fmt.Sprintf("avg %.2f, p50 %.2f, p90 %.2f, p99 %.2f, min %.2f, max %.2f", d.avg, d.p50, d.p90, d.p99, d.min, d.max)
+}
+
+type blockStats struct {
+	start        uint64
+	end          uint64
+	blocks       int
+	transactions int
+	// tps is the throughput over the whole range in transactions per second.
+	tps                  float64
+	interval             distribution
+	collections          distribution
+	transactionsPerBlock distribution
+	blockTPS             distribution
+	sealLag              distribution
+}
+
+func newBlockStats(blocks []blockInfo) *blockStats {
+	stats := &blockStats{blocks: len(blocks)}
+	if len(blocks) == 0 {
+		return stats
+	}
+
+	stats.start = blocks[0].height
+	stats.end = blocks[len(blocks)-1].height
+
+	intervals := make([]float64, 0, len(blocks))
+	collections := make([]float64, 0, len(blocks))
+	transactions := make([]float64, 0, len(blocks))
+	blockTPS := make([]float64, 0, len(blocks))
+	sealLag := make([]float64, 0, len(blocks))
+
+	for i, block := range blocks {
+		stats.transactions += block.transactions
+		collections = append(collections, float64(block.collections))
+		transactions = append(transactions, float64(block.transactions))
+
+		if i > 0 && block.interval > 0 {
+			intervals = append(intervals, block.interval.Seconds())
+			blockTPS = append(blockTPS, float64(block.transactions)/block.interval.Seconds())
+		}
+		if block.sealLag != nil {
+			sealLag = append(sealLag, float64(*block.sealLag))
+		}
+	}
+
+	// the transactions of the first block were produced before the range started
+	elapsed := blocks[len(blocks)-1].timestamp.Sub(blocks[0].timestamp).Seconds()
+	if elapsed > 0 {
+		stats.tps = float64(stats.transactions-blocks[0].transactions) / elapsed
+	}
+
+	stats.interval = newDistribution(intervals)
+	stats.collections = newDistribution(collections)
+	stats.transactionsPerBlock = newDistribution(transactions)
+	stats.blockTPS = newDistribution(blockTPS)
+	stats.sealLag = newDistribution(sealLag)
+
+	return stats
+}
+
+func (s *blockStats) JSON() any {
+	return map[string]any{
+		"start":                s.start,
+		"end":                  s.end,
+		"blocks":               s.blocks,
+		"transactions":         s.transactions,
+		"tps":                  s.tps,
+		"blockInterval":        s.interval.JSON(),
+		"collectionsPerBlock":  s.collections.JSON(),
+		"transactionsPerBlock": s.transactionsPerBlock.JSON(),
+		"blockTps":             s.blockTPS.JSON(),
+		"sealLag":              s.sealLag.JSON(),
+	}
+}
+
+func (s *blockStats) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Blocks\t%d to %d (%d)\n", s.start, s.end, s.blocks)
+	_, _ = fmt.Fprintf(writer, "Transactions\t%d\n", s.transactions)
+	_, _ = fmt.Fprintf(writer, "Throughput\t%.2f tx/s\n", s.tps)
+	_, _ = fmt.Fprintf(writer, "Block Interval (s)\t%s\n", s.interval)
+	_, _ = fmt.Fprintf(writer, "Collections per Block\t%s\n", s.collections)
+	_, _ = fmt.Fprintf(writer, "Transactions per Block\t%s\n", s.transactionsPerBlock)
+	_, _ = fmt.Fprintf(writer, "Block Throughput (tx/s)\t%s\n", s.blockTPS)
+	_, _ = fmt.Fprintf(writer, "Seal Lag (blocks)\t%s\n", s.sealLag)
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (s *blockStats) Oneliner() string {
+	return fmt.Sprintf(
+		"Blocks: %d-%d, Transactions: %d, TPS: %.2f, Interval p50: %.2fs, Seal Lag p50: %.0f",
+		s.start, s.end, s.transactions, s.tps, s.interval.p50, s.sealLag.p50,
+	)
+}
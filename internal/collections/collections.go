@@ -26,6 +26,8 @@ import (
 	"github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
 
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/transactions"
 	"github.com/onflow/flow-cli/internal/util"
 )
 
@@ -42,9 +44,11 @@ func init() {
 
 type collectionResult struct {
 	*flow.Collection
+	results  transactions.Results
+	included []string
 }
 
-func (c *collectionResult) JSON() any {
+func (c *collectionResult) transactionIDs() []string {
 	txIDs := make([]string, 0)
 
 	for _, tx := range c.Collection.TransactionIDs {
@@ -54,14 +58,44 @@ func (c *collectionResult) JSON() any {
 	return txIDs
 }
 
+func (c *collectionResult) JSON() any {
+	includeResults := command.ContainsFlag(c.included, "transaction-results")
+	includeEvents := command.ContainsFlag(c.included, "events")
+	if !includeResults && !includeEvents {
+		return c.transactionIDs()
+	}
+
+	result := map[string]any{
+		"id":           c.Collection.ID().String(),
+		"transactions": c.transactionIDs(),
+	}
+	if includeResults {
+		result["transactionResults"] = c.results.TableJSON()
+	}
+	if includeEvents {
+		result["transactionEvents"] = c.results.EventsJSON()
+	}
+
+	return result
+}
+
 func (c *collectionResult) String() string {
 	var b bytes.Buffer
 	writer := util.CreateTabWriter(&b)
 
 	_, _ = fmt.Fprintf(writer, "Collection ID %s:\n", c.Collection.ID())
 
-	for _, tx := range c.Collection.TransactionIDs {
-		_, _ = fmt.Fprintf(writer, "%s\n", tx.String())
+	if command.ContainsFlag(c.included, "transaction-results") {
+		c.results.WriteTable(writer)
+	} else {
+		for _, tx := range c.Collection.TransactionIDs {
+			_, _ = fmt.Fprintf(writer, "%s\n", tx.String())
+		}
+	}
+
+	if command.ContainsFlag(c.included, "events") {
+		_, _ = fmt.Fprintf(writer, "\n")
+		c.results.WriteEvents(writer)
 	}
 
 	_ = writer.Flush()
@@ -70,5 +104,5 @@ func (c *collectionResult) String() string {
 }
 
 func (c *collectionResult) Oneliner() string {
-	return strings.Join(c.JSON().([]string), ",")
+	return strings.Join(c.transactionIDs(), ",")
 }
@@ -19,6 +19,7 @@
 package collections
 
 import (
+	"context"
 	"testing"
 
 	"github.com/onflow/flow-go-sdk"
@@ -26,6 +27,8 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/flowkit/tests"
+
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/util"
 )
@@ -45,4 +48,23 @@ func Test_Get(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, result)
 	})
+	t.Run("Success include transaction results", func(t *testing.T) {
+		collectionFlags.Include = []string{"transaction-results"}
+		defer func() { collectionFlags.Include = nil }()
+
+		srv.GetCollection.Return(&flow.Collection{TransactionIDs: []flow.Identifier{{1}, {2}}}, nil)
+		txResult := tests.NewTransactionResult(nil)
+		srv.GetTransactionByID.Return(func(_ context.Context, id flow.Identifier, _ bool) (*flow.Transaction, *flow.TransactionResult, error) {
+			return flow.NewTransaction().SetScript(id.Bytes()), txResult, nil
+		})
+
+		result, err := get([]string{util.TestID.String()}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+
+		r := result.(*collectionResult)
+		require.Len(t, r.results, 2)
+		assert.Equal(t, []byte{1}, r.results[0].Transaction.Script[:1])
+		assert.Len(t, r.JSON().(map[string]any)["transactionResults"], 2)
+		assert.Contains(t, r.String(), "SEALED")
+	})
 }
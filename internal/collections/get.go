@@ -29,18 +29,23 @@ import (
 	"github.com/onflow/flowkit/output"
 
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/transactions"
 )
 
-type flagsCollections struct{}
+type flagsCollections struct {
+	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: transaction-results, events."`
+}
 
 var collectionFlags = flagsCollections{}
 
 var getCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "get <collection_id>",
-		Short:   "Get collection info",
-		Example: "flow collections get 270d...9c31e",
-		Args:    cobra.ExactArgs(1),
+		Use:   "get <collection_id>",
+		Short: "Get collection info",
+		Example: `flow collections get 270d...9c31e
+
+flow collections get 270d...9c31e --include transaction-results,events`,
+		Args: cobra.ExactArgs(1),
 	},
 	Flags: &collectionFlags,
 	Run:   get,
@@ -63,5 +68,18 @@ func get(
 		return nil, err
 	}
 
-	return &collectionResult{collection}, nil
+	var results transactions.Results
+	if command.ContainsFlag(collectionFlags.Include, "transaction-results") ||
+		command.ContainsFlag(collectionFlags.Include, "events") {
+		results, err = transactions.GetResults(flow, collection.TransactionIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &collectionResult{
+		Collection: collection,
+		results:    results,
+		included:   collectionFlags.Include,
+	}, nil
 }
@@ -47,6 +47,7 @@ import (
 	"github.com/onflow/flowkit/output"
 
 	"github.com/onflow/flow-cli/build"
+	"github.com/onflow/flow-cli/internal/keybackend"
 	"github.com/onflow/flow-cli/internal/settings"
 	"github.com/onflow/flow-cli/internal/util"
 )
@@ -111,6 +112,9 @@ func (c Command) AddToParent(parent *cobra.Command) {
 		if !errors.Is(confErr, config.ErrDoesNotExist) {
 			handleError("Config Error", confErr)
 		}
+		if state != nil {
+			keybackend.ResolveAccountKeys(state)
+		}
 
 		network, err := resolveHost(state, Flags.Host, Flags.HostNetworkKey, Flags.Network)
 		handleError("Host Error", err)
@@ -41,6 +41,8 @@ var Cmd = &cobra.Command{
 
 func init() {
 	getCommand.AddToParent(Cmd)
+	watchCommand.AddToParent(Cmd)
+	typesCommand.AddToParent(Cmd)
 }
 
 type EventResult struct {
@@ -19,9 +19,13 @@
 package events
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/onflow/cadence"
 	"github.com/onflow/flow-go-sdk"
@@ -29,6 +33,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/config"
 	"github.com/onflow/flowkit/tests"
 
 	"github.com/onflow/flow-cli/internal/command"
@@ -111,3 +116,357 @@ Events Block #1:
 		"values":        json.RawMessage{0x7b, 0x22, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x3a, 0x7b, 0x22, 0x69, 0x64, 0x22, 0x3a, 0x22, 0x41, 0x2e, 0x66, 0x6f, 0x6f, 0x22, 0x2c, 0x22, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0x3a, 0x5b, 0x7b, 0x22, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x3a, 0x7b, 0x22, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x3a, 0x22, 0x31, 0x22, 0x2c, 0x22, 0x74, 0x79, 0x70, 0x65, 0x22, 0x3a, 0x22, 0x49, 0x6e, 0x74, 0x22, 0x7d, 0x2c, 0x22, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3a, 0x22, 0x62, 0x61, 0x72, 0x22, 0x7d, 0x5d, 0x7d, 0x2c, 0x22, 0x74, 0x79, 0x70, 0x65, 0x22, 0x3a, 0x22, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x7d, 0xa},
 	}}, event.JSON())
 }
+
+func Test_Watch(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	t.Run("Success follows chain head", func(t *testing.T) {
+		watchFlags = flagsWatch{Batch: 2}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		block := tests.NewBlock()
+		block.Height = 12
+		srv.GetBlock.Run(func(args mock.Arguments) {
+			srv.GetBlock.Return(block, nil)
+		})
+
+		var ranges [][2]uint64
+		srv.GetEvents.Run(func(args mock.Arguments) {
+			start, end := args.Get(2).(uint64), args.Get(3).(uint64)
+			ranges = append(ranges, [2]uint64{start, end})
+			srv.GetEvents.Return([]flow.BlockEvents{{Height: start, Events: []flow.Event{*tests.NewEvent(0, "A.foo", nil, nil)}}}, nil)
+		})
+
+		var heights []uint64
+		err := watchEvents(ctx, srv.Mock, []string{"A.foo"}, 9, time.Millisecond, func(events []flow.BlockEvents, height uint64) error {
+			heights = append(heights, height)
+			if height == 12 {
+				cancel()
+			}
+			return nil
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, [][2]uint64{{9, 10}, {11, 12}}, ranges)
+		assert.Equal(t, []uint64{10, 12}, heights)
+	})
+
+	t.Run("Success resume from checkpoint", func(t *testing.T) {
+		watchFlags = flagsWatch{Start: 5, Checkpoint: "events.checkpoint"}
+		watchStartChanged = true
+		defer func() { watchStartChanged = false }()
+
+		height, err := watchStartHeight(context.Background(), rw, srv.Mock)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(5), height)
+
+		assert.NoError(t, saveCheckpoint(rw, 41))
+		height, err = watchStartHeight(context.Background(), rw, srv.Mock)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(42), height)
+	})
+
+	t.Run("Success start from genesis", func(t *testing.T) {
+		watchFlags = flagsWatch{Start: 0}
+		watchStartChanged = true
+		defer func() { watchStartChanged = false }()
+
+		height, err := watchStartHeight(context.Background(), rw, srv.Mock)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(0), height)
+	})
+
+	t.Run("Success sort events", func(t *testing.T) {
+		first := *tests.NewEvent(1, "A.foo", nil, nil)
+		second := *tests.NewEvent(0, "A.bar", nil, nil)
+		second.TransactionIndex = 2
+
+		sorted := sortBlockEvents([]flow.BlockEvents{
+			{Height: 2, Events: []flow.Event{second}},
+			{Height: 1, Events: []flow.Event{first}},
+			{Height: 2, Events: []flow.Event{first}},
+			{Height: 3},
+		})
+		assert.Len(t, sorted, 2)
+		assert.Equal(t, uint64(1), sorted[0].Height)
+		assert.Equal(t, []flow.Event{first, second}, sorted[1].Events)
+	})
+
+	t.Run("Fail invalid interval", func(t *testing.T) {
+		watchFlags = flagsWatch{Interval: "soon", Batch: 1}
+		_, err := watch([]string{"A.foo"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "invalid interval soon, must be a positive duration such as 2s")
+	})
+
+	t.Run("Fail save output", func(t *testing.T) {
+		watchFlags = flagsWatch{Interval: "2s", Batch: 1}
+		_, err := watch([]string{"A.foo"}, command.GlobalFlags{Save: "events.json"}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "save and filter flags are not supported when watching events, redirect the output instead")
+	})
+}
+
+func Test_Filter(t *testing.T) {
+	to := cadence.NewOptional(cadence.NewAddress(flow.HexToAddress("01cf0e2f2f715450")))
+	deposit := func(amount string, receiver cadence.Value) flow.Event {
+		value, err := cadence.NewUFix64(amount)
+		assert.NoError(t, err)
+
+		return *tests.NewEvent(
+			0,
+			"A.1654653399040a61.FlowToken.TokensDeposited",
+			[]cadence.Field{
+				{Identifier: "amount", Type: cadence.UFix64Type{}},
+				{Identifier: "to", Type: &cadence.OptionalType{Type: cadence.AddressType{}}},
+			},
+			[]cadence.Value{value, receiver},
+		)
+	}
+
+	events := []flow.BlockEvents{{
+		Height: 1,
+		Events: []flow.Event{
+			deposit("5.0", to),
+			deposit("15.0", to),
+			deposit("20.0", cadence.NewOptional(nil)),
+		},
+	}}
+
+	t.Run("Success where", func(t *testing.T) {
+		tests := []struct {
+			where   string
+			matches int
+		}{
+			{where: "to == 0x01cf0e2f2f715450 && amount > 10.0", matches: 1},
+			{where: "to == 0x1cf0e2f2f715450", matches: 2},
+			{where: "amount >= 5 && !(amount == 15)", matches: 2},
+			{where: "to == nil || amount < 6", matches: 2},
+			{where: "to != nil", matches: 2},
+			{where: "missing == 1", matches: 0},
+		}
+
+		for _, test := range tests {
+			filter, err := parseWhere(test.where)
+			assert.NoError(t, err, test.where)
+
+			filtered := filterEvents(events, filter, nil)
+			assert.Len(t, filtered[0].Events, test.matches, test.where)
+		}
+	})
+
+	t.Run("Success fields", func(t *testing.T) {
+		filtered := filterEvents(events, nil, []string{"to", "unknown"})
+
+		event := filtered[0].Events[0].Value
+		assert.Len(t, event.Fields, 1)
+		assert.Equal(t, "to", event.EventType.Fields[0].Identifier)
+		assert.Equal(t, to, event.Fields[0])
+	})
+
+	t.Run("Fail invalid where", func(t *testing.T) {
+		_, err := parseWhere("amount >")
+		assert.EqualError(t, err, "invalid where expression: expected value after amount > but got end of expression")
+
+		_, err = parseWhere("amount = 1")
+		assert.EqualError(t, err, "invalid where expression: invalid operator =")
+
+		_, err = parseWhere("(amount == 1")
+		assert.EqualError(t, err, "invalid where expression: expected ) but got end of expression")
+	})
+}
+
+func Test_Export(t *testing.T) {
+	_, _, rw := util.TestMocks(t)
+
+	amount, err := cadence.NewUFix64("10.5")
+	assert.NoError(t, err)
+	deposited := *tests.NewEvent(
+		1,
+		"A.1654653399040a61.FlowToken.TokensDeposited",
+		[]cadence.Field{
+			{Identifier: "amount", Type: cadence.UFix64Type{}},
+			{Identifier: "to", Type: &cadence.OptionalType{Type: cadence.AddressType{}}},
+		},
+		[]cadence.Value{amount, cadence.NewOptional(cadence.NewAddress(flow.HexToAddress("01cf0e2f2f715450")))},
+	)
+	withdrawn := *tests.NewEvent(
+		2,
+		"A.1654653399040a61.FlowToken.TokensWithdrawn",
+		[]cadence.Field{{Identifier: "from", Type: &cadence.OptionalType{Type: cadence.AddressType{}}}},
+		[]cadence.Value{cadence.NewOptional(nil)},
+	)
+
+	events := []flow.BlockEvents{{
+		Height:         7,
+		BlockTimestamp: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Events:         []flow.Event{deposited, withdrawn},
+	}}
+
+	t.Run("Success CSV", func(t *testing.T) {
+		result, err := exportEvents("events.csv", events, rw)
+		assert.NoError(t, err)
+		assert.Equal(t, []exportedTable{
+			{name: "events.A.1654653399040a61.FlowToken.TokensDeposited.csv", inserted: 1},
+			{name: "events.A.1654653399040a61.FlowToken.TokensWithdrawn.csv", inserted: 1},
+		}, result.tables)
+
+		data, err := rw.ReadFile("events.A.1654653399040a61.FlowToken.TokensDeposited.csv")
+		assert.NoError(t, err)
+		assert.Equal(t, strings.Join([]string{
+			"block_height,block_timestamp,transaction_id,transaction_index,event_index,amount,to",
+			"7,2024-01-02T03:04:05Z,0000000000000000000000000000000000000000000000000000000000000000,1,1,10.50000000,0x01cf0e2f2f715450",
+			"",
+		}, "\n"), string(data))
+
+		// appending the same events again is idempotent
+		more := append(events, flow.BlockEvents{Height: 8, Events: []flow.Event{deposited}})
+		result, err = exportEvents("events.csv", more, rw)
+		assert.NoError(t, err)
+		assert.Equal(t, exportedTable{name: "events.A.1654653399040a61.FlowToken.TokensDeposited.csv", inserted: 1, skipped: 1}, result.tables[0])
+	})
+
+	t.Run("Success CSV append new struct columns", func(t *testing.T) {
+		receiverType := &cadence.StructType{
+			QualifiedIdentifier: "Receiver",
+			Fields:              []cadence.Field{{Identifier: "address", Type: cadence.AddressType{}}},
+		}
+		fields := []cadence.Field{{Identifier: "receiver", Type: &cadence.OptionalType{Type: receiverType}}}
+		received := func(value cadence.Value) flow.BlockEvents {
+			return flow.BlockEvents{
+				Height:         9,
+				BlockTimestamp: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+				Events:         []flow.Event{*tests.NewEvent(1, "A.1654653399040a61.Token.Received", fields, []cadence.Value{value})},
+			}
+		}
+		receiver := cadence.NewStruct([]cadence.Value{cadence.NewAddress(flow.HexToAddress("01"))}).WithType(receiverType)
+
+		_, err := exportEvents("received.csv", []flow.BlockEvents{received(cadence.NewOptional(nil))}, rw)
+		assert.NoError(t, err)
+
+		more := received(cadence.NewOptional(receiver))
+		more.Height = 10
+		result, err := exportEvents("received.csv", []flow.BlockEvents{more}, rw)
+		assert.NoError(t, err)
+		assert.Equal(t, []exportedTable{{name: "received.A.1654653399040a61.Token.Received.csv", inserted: 1}}, result.tables)
+
+		data, err := rw.ReadFile("received.A.1654653399040a61.Token.Received.csv")
+		assert.NoError(t, err)
+		assert.Equal(t, strings.Join([]string{
+			"block_height,block_timestamp,transaction_id,transaction_index,event_index,receiver,receiver.address",
+			"9,2024-01-02T03:04:05Z,0000000000000000000000000000000000000000000000000000000000000000,1,1,,",
+			"10,2024-01-02T03:04:05Z,0000000000000000000000000000000000000000000000000000000000000000,1,1,,0x0000000000000001",
+			"",
+		}, "\n"), string(data))
+	})
+
+	t.Run("Success SQLite", func(t *testing.T) {
+		location := filepath.Join(t.TempDir(), "events.sqlite")
+
+		result, err := exportEvents(location, events, rw)
+		assert.NoError(t, err)
+		assert.Equal(t, exportedTable{name: "A_1654653399040a61_FlowToken_TokensDeposited", inserted: 1}, result.tables[0])
+
+		result, err = exportEvents(location, events, rw)
+		assert.NoError(t, err)
+		assert.Equal(t, exportedTable{name: "A_1654653399040a61_FlowToken_TokensDeposited", skipped: 1}, result.tables[0])
+
+		db, err := sql.Open("sqlite", location)
+		assert.NoError(t, err)
+		defer db.Close()
+
+		var to, value string
+		err = db.QueryRow(`SELECT "to", amount FROM A_1654653399040a61_FlowToken_TokensDeposited WHERE block_height = 7`).Scan(&to, &value)
+		assert.NoError(t, err)
+		assert.Equal(t, "0x01cf0e2f2f715450", to)
+		assert.Equal(t, "10.50000000", value)
+	})
+
+	t.Run("Fail unsupported export", func(t *testing.T) {
+		_, err := exportEvents("events.parquet", events, rw)
+		assert.EqualError(t, err, "unsupported export file events.parquet, use a .csv or .sqlite file")
+	})
+}
+
+func Test_Types(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	const tokenContract = `
+pub contract Token {
+    pub event TokensWithdrawn(amount: UFix64, from: Address?)
+    pub event TokensDeposited(amount: UFix64, to: Address?)
+
+    pub resource Vault {}
+}
+`
+	address := flow.HexToAddress("0x1654653399040a61")
+	srv.GetAccount.Run(func(args mock.Arguments) {
+		srv.GetAccount.Return(&flow.Account{
+			Address:   address,
+			Contracts: map[string][]byte{"Token": []byte(tokenContract)},
+		}, nil)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		result, err := types([]string{"0x1654653399040a61"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.NoError(t, err)
+
+		events := result.(*typesResult).events
+		assert.Len(t, events, 2)
+		assert.Equal(t, "A.1654653399040a61.Token.TokensWithdrawn", events[0].id)
+		assert.Equal(t, []eventField{{"amount", "UFix64"}, {"from", "Address?"}}, events[0].fields)
+		assert.Equal(t, "A.1654653399040a61.Token.TokensDeposited", events[1].id)
+		assert.Contains(t, result.String(), "to (Address?)")
+	})
+
+	t.Run("Success expand wildcard", func(t *testing.T) {
+		eventTypes, err := expandEventTypes(srv.Mock, []string{"A.1654653399040a61.Token.*", "A.foo.Bar.Baz"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"A.1654653399040a61.Token.TokensWithdrawn",
+			"A.1654653399040a61.Token.TokensDeposited",
+			"A.foo.Bar.Baz",
+		}, eventTypes)
+	})
+
+	t.Run("Fail missing contract", func(t *testing.T) {
+		_, err := expandEventTypes(srv.Mock, []string{"A.1654653399040a61.Other.*"})
+		assert.EqualError(t, err, "contract Other is not deployed to account 0x1654653399040a61")
+	})
+
+	t.Run("Fail invalid address", func(t *testing.T) {
+		_, err := types([]string{"Unknown"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "invalid address or contract name: Unknown")
+	})
+}
+
+func Test_ResolveEventTypes(t *testing.T) {
+	_, state, _ := util.TestMocks(t)
+	state.Contracts().AddOrUpdate(config.Contract{
+		Name:     "FlowToken",
+		Location: "cadence/contracts/FlowToken.cdc",
+		Aliases: config.Aliases{{
+			Network: config.TestnetNetwork.Name,
+			Address: flow.HexToAddress("0x7e60df042a9c0868"),
+		}},
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		eventTypes, err := resolveEventTypes(state, config.TestnetNetwork, util.NoLogger, []string{
+			"FlowToken.TokensDeposited",
+			"FlowToken.*",
+			"flow.AccountCreated",
+			"A.1654653399040a61.FlowToken.TokensWithdrawn",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"A.7e60df042a9c0868.FlowToken.TokensDeposited",
+			"A.7e60df042a9c0868.FlowToken.*",
+			"flow.AccountCreated",
+			"A.1654653399040a61.FlowToken.TokensWithdrawn",
+		}, eventTypes)
+	})
+
+	t.Run("Fail not on network", func(t *testing.T) {
+		_, err := resolveEventTypes(state, config.MainnetNetwork, util.NoLogger, []string{"FlowToken.TokensDeposited"})
+		assert.EqualError(t, err, "contract FlowToken is not deployed or aliased on network mainnet")
+	})
+}
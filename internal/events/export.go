@@ -0,0 +1,414 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	_ "github.com/glebarez/go-sqlite"
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/output"
+
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// exportColumns are the columns identifying every exported event, the event fields follow them.
+var exportColumns = []string{"block_height", "block_timestamp", "transaction_id", "transaction_index", "event_index"}
+
+// exportTable contains the flattened events of a single event type.
+type exportTable struct {
+	eventType string
+	columns   []string
+	rows      []map[string]string
+}
+
+func (t *exportTable) addColumn(column string) {
+	for _, c := range t.columns {
+		if c == column {
+			return
+		}
+	}
+	t.columns = append(t.columns, column)
+}
+
+// key identifies the event row, rows with keys already in the export are skipped when appending.
+func rowKey(row map[string]string) string {
+	return fmt.Sprintf("%s/%s/%s", row["block_height"], row["transaction_id"], row["event_index"])
+}
+
+// flattenEvents groups the events by type and flattens the event fields into columns,
+// nested struct fields are named using the dot separated path.
+func flattenEvents(blockEvents []flow.BlockEvents) []*exportTable {
+	tables := make(map[string]*exportTable)
+	for _, blockEvent := range blockEvents {
+		for _, event := range blockEvent.Events {
+			table, ok := tables[event.Type]
+			if !ok {
+				table = &exportTable{eventType: event.Type, columns: append([]string{}, exportColumns...)}
+				tables[event.Type] = table
+			}
+
+			row := map[string]string{
+				"block_height":      fmt.Sprint(blockEvent.Height),
+				"block_timestamp":   blockEvent.BlockTimestamp.UTC().Format(time.RFC3339Nano),
+				"transaction_id":    event.TransactionID.String(),
+				"transaction_index": fmt.Sprint(event.TransactionIndex),
+				"event_index":       fmt.Sprint(event.EventIndex),
+			}
+
+			values := event.Value.GetFieldValues()
+			for i, field := range event.Value.GetFields() {
+				if i < len(values) {
+					flattenValue(table, row, field.Identifier, values[i])
+				}
+			}
+
+			table.rows = append(table.rows, row)
+		}
+	}
+
+	result := make([]*exportTable, 0, len(tables))
+	for _, table := range tables {
+		result = append(result, table)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].eventType < result[j].eventType
+	})
+
+	return result
+}
+
+func flattenValue(table *exportTable, row map[string]string, column string, value cadence.Value) {
+	if optional, ok := value.(cadence.Optional); ok {
+		value = optional.Value
+	}
+
+	if c, ok := value.(composite); ok {
+		values := c.GetFieldValues()
+		for i, field := range c.GetFields() {
+			if i < len(values) {
+				flattenValue(table, row, fmt.Sprintf("%s.%s", column, field.Identifier), values[i])
+			}
+		}
+		return
+	}
+
+	table.addColumn(column)
+	switch v := value.(type) {
+	case nil:
+		row[column] = ""
+	case cadence.String:
+		row[column] = string(v)
+	case cadence.Address:
+		row[column] = fmt.Sprintf("0x%s", flow.Address(v).Hex())
+	default:
+		row[column] = v.String()
+	}
+}
+
+// exportEvents writes the events to the CSV or SQLite export depending on the file extension.
+func exportEvents(location string, blockEvents []flow.BlockEvents, rw flowkit.ReaderWriter) (*exportResult, error) {
+	tables := flattenEvents(blockEvents)
+
+	switch strings.ToLower(filepath.Ext(location)) {
+	case ".csv":
+		return exportCSV(location, tables, rw)
+	case ".sqlite", ".sqlite3", ".db":
+		return exportSQLite(location, tables)
+	default:
+		return nil, fmt.Errorf("unsupported export file %s, use a .csv or .sqlite file", location)
+	}
+}
+
+// csvFile returns the CSV file of the event type, named after the export file and the event type.
+func csvFile(location string, eventType string) string {
+	return fmt.Sprintf("%s.%s.csv", strings.TrimSuffix(location, filepath.Ext(location)), eventType)
+}
+
+// exportCSV writes a CSV file for each event type, appending to existing files and skipping the rows already exported.
+func exportCSV(location string, tables []*exportTable, rw flowkit.ReaderWriter) (*exportResult, error) {
+	result := &exportResult{location: location}
+
+	for _, table := range tables {
+		file := csvFile(location, table.eventType)
+
+		var records [][]string
+		data, err := rw.ReadFile(file)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		if err == nil {
+			records, err = csv.NewReader(bytes.NewReader(data)).ReadAll()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read existing export %s: %w", file, err)
+			}
+		}
+
+		columns := table.columns
+		existing := make(map[string]bool)
+		if len(records) > 0 {
+			// the columns depend on the field values, a nil optional struct is a single column while
+			// a struct is a column for each field, so new columns are added and the existing rows padded
+			columns = records[0]
+			for _, column := range table.columns {
+				if !contains(columns, column) {
+					columns = append(columns, column)
+				}
+			}
+			records[0] = columns
+			for i, record := range records[1:] {
+				for len(record) < len(columns) {
+					record = append(record, "")
+				}
+				records[i+1] = record
+				row := make(map[string]string)
+				for i, column := range columns {
+					if i < len(record) {
+						row[column] = record[i]
+					}
+				}
+				existing[rowKey(row)] = true
+			}
+		} else {
+			records = [][]string{columns}
+		}
+
+		inserted := 0
+		for _, row := range table.rows {
+			if existing[rowKey(row)] {
+				continue
+			}
+			existing[rowKey(row)] = true
+
+			record := make([]string, len(columns))
+			for i, column := range columns {
+				record[i] = row[column]
+			}
+			records = append(records, record)
+			inserted++
+		}
+
+		var b bytes.Buffer
+		writer := csv.NewWriter(&b)
+		if err := writer.WriteAll(records); err != nil {
+			return nil, err
+		}
+
+		if err := rw.WriteFile(file, b.Bytes(), os.FileMode(0644)); err != nil {
+			return nil, fmt.Errorf("failed to write export %s: %w", file, err)
+		}
+
+		result.tables = append(result.tables, exportedTable{
+			name:     file,
+			inserted: inserted,
+			skipped:  len(table.rows) - inserted,
+		})
+	}
+
+	return result, nil
+}
+
+var tableNameReplacer = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// tableName returns the SQLite table name of the event type.
+func tableName(eventType string) string {
+	return tableNameReplacer.ReplaceAllString(eventType, "_")
+}
+
+func quoteIdentifier(name string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(name, `"`, `""`))
+}
+
+// exportSQLite writes a table for each event type, the rows already exported are ignored
+// using the primary key of the block height, transaction ID and event index.
+func exportSQLite(location string, tables []*exportTable) (*exportResult, error) {
+	db, err := sql.Open("sqlite", location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open export %s: %w", location, err)
+	}
+	defer db.Close()
+
+	result := &exportResult{location: location}
+	for _, table := range tables {
+		inserted, err := exportSQLiteTable(db, table)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export %s: %w", table.eventType, err)
+		}
+
+		result.tables = append(result.tables, exportedTable{
+			name:     tableName(table.eventType),
+			inserted: inserted,
+			skipped:  len(table.rows) - inserted,
+		})
+	}
+
+	return result, nil
+}
+
+func exportSQLiteTable(db *sql.DB, table *exportTable) (int, error) {
+	name := quoteIdentifier(tableName(table.eventType))
+
+	_, err := db.Exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	block_height INTEGER NOT NULL,
+	block_timestamp TEXT NOT NULL,
+	transaction_id TEXT NOT NULL,
+	transaction_index INTEGER NOT NULL,
+	event_index INTEGER NOT NULL,
+	PRIMARY KEY (block_height, transaction_id, event_index)
+)`, name))
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := db.Query(fmt.Sprintf("SELECT name FROM pragma_table_info('%s')", tableName(table.eventType)))
+	if err != nil {
+		return 0, err
+	}
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			_ = rows.Close()
+			return 0, err
+		}
+		existing[column] = true
+	}
+	_ = rows.Close()
+
+	for _, column := range table.columns {
+		if existing[column] {
+			continue
+		}
+		_, err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s TEXT", name, quoteIdentifier(column)))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	quoted := make([]string, len(table.columns))
+	placeholders := make([]string, len(table.columns))
+	for i, column := range table.columns {
+		quoted[i] = quoteIdentifier(column)
+		placeholders[i] = "?"
+	}
+	insert := fmt.Sprintf(
+		"INSERT OR IGNORE INTO %s (%s) VALUES (%s)",
+		name,
+		strings.Join(quoted, ", "),
+		strings.Join(placeholders, ", "),
+	)
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	inserted := 0
+	for _, row := range table.rows {
+		values := make([]any, len(table.columns))
+		for i, column := range table.columns {
+			values[i] = row[column]
+		}
+
+		res, err := tx.Exec(insert, values...)
+		if err != nil {
+			return 0, err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		inserted += int(affected)
+	}
+
+	return inserted, tx.Commit()
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+type exportedTable struct {
+	name     string
+	inserted int
+	skipped  int
+}
+
+type exportResult struct {
+	location string
+	tables   []exportedTable
+}
+
+func (r *exportResult) JSON() any {
+	tables := make([]any, 0, len(r.tables))
+	for _, table := range r.tables {
+		tables = append(tables, map[string]any{
+			"table":    table.name,
+			"inserted": table.inserted,
+			"skipped":  table.skipped,
+		})
+	}
+
+	return map[string]any{
+		"export": r.location,
+		"tables": tables,
+	}
+}
+
+func (r *exportResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "%s Events exported to %s\n\n", output.SuccessEmoji(), r.location)
+	_, _ = fmt.Fprintf(writer, "Table\t Inserted\t Skipped\n")
+	for _, table := range r.tables {
+		_, _ = fmt.Fprintf(writer, "%s\t %d\t %d\n", table.name, table.inserted, table.skipped)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *exportResult) Oneliner() string {
+	inserted := 0
+	for _, table := range r.tables {
+		inserted += table.inserted
+	}
+
+	return fmt.Sprintf("Export: %s, Tables: %d, Inserted: %d", r.location, len(r.tables), inserted)
+}
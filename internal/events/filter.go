@@ -0,0 +1,449 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+)
+
+// composite is a cadence value with named fields such as events and structs.
+type composite interface {
+	GetFields() []cadence.Field
+	GetFieldValues() []cadence.Value
+}
+
+// fieldValue returns the value of the field, nested fields are referenced with a dot separated path.
+func fieldValue(value cadence.Value, path string) (cadence.Value, bool) {
+	for _, name := range strings.Split(path, ".") {
+		if optional, ok := value.(cadence.Optional); ok {
+			value = optional.Value
+		}
+
+		c, ok := value.(composite)
+		if !ok {
+			return nil, false
+		}
+
+		found := false
+		values := c.GetFieldValues()
+		for i, field := range c.GetFields() {
+			if field.Identifier == name && i < len(values) {
+				value = values[i]
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+
+	return value, true
+}
+
+// projectEvent returns the event with only the provided fields in the provided order,
+// fields which the event does not have are skipped.
+func projectEvent(event flow.Event, fields []string) flow.Event {
+	if len(fields) == 0 {
+		return event
+	}
+
+	eventType := event.Value.EventType
+	projectedFields := make([]cadence.Field, 0, len(fields))
+	projectedValues := make([]cadence.Value, 0, len(fields))
+	for _, name := range fields {
+		for i, field := range eventType.Fields {
+			if field.Identifier == name {
+				projectedFields = append(projectedFields, field)
+				projectedValues = append(projectedValues, event.Value.Fields[i])
+			}
+		}
+	}
+
+	event.Value = cadence.NewEvent(projectedValues).WithType(&cadence.EventType{
+		Location:            eventType.Location,
+		QualifiedIdentifier: eventType.QualifiedIdentifier,
+		Fields:              projectedFields,
+		Initializer:         eventType.Initializer,
+	})
+
+	return event
+}
+
+// filterEvents returns the block events with only the events matching the filter and projected to the fields.
+func filterEvents(blockEvents []flow.BlockEvents, filter whereExpr, fields []string) []flow.BlockEvents {
+	if filter == nil && len(fields) == 0 {
+		return blockEvents
+	}
+
+	result := make([]flow.BlockEvents, 0, len(blockEvents))
+	for _, blockEvent := range blockEvents {
+		events := make([]flow.Event, 0, len(blockEvent.Events))
+		for _, event := range blockEvent.Events {
+			if filter != nil && !filter.eval(event.Value) {
+				continue
+			}
+			events = append(events, projectEvent(event, fields))
+		}
+
+		blockEvent.Events = events
+		result = append(result, blockEvent)
+	}
+
+	return result
+}
+
+// whereExpr is a boolean expression evaluated against the event fields.
+type whereExpr interface {
+	eval(event cadence.Value) bool
+}
+
+type andExpr struct{ left, right whereExpr }
+
+func (e andExpr) eval(event cadence.Value) bool { return e.left.eval(event) && e.right.eval(event) }
+
+type orExpr struct{ left, right whereExpr }
+
+func (e orExpr) eval(event cadence.Value) bool { return e.left.eval(event) || e.right.eval(event) }
+
+type notExpr struct{ expr whereExpr }
+
+func (e notExpr) eval(event cadence.Value) bool { return !e.expr.eval(event) }
+
+type literalKind int
+
+const (
+	literalString literalKind = iota
+	literalNumber
+	literalAddress
+	literalBool
+	literalNil
+)
+
+type literal struct {
+	kind   literalKind
+	raw    string
+	number *big.Rat
+}
+
+// compareExpr compares the event field with the literal, events without the field never match.
+type compareExpr struct {
+	field string
+	op    string
+	value literal
+}
+
+func (e compareExpr) eval(event cadence.Value) bool {
+	value, ok := fieldValue(event, e.field)
+	if !ok {
+		return false
+	}
+	if optional, ok := value.(cadence.Optional); ok {
+		value = optional.Value
+	}
+
+	if e.value.kind == literalNil || value == nil {
+		isNil := value == nil && e.value.kind == literalNil
+		switch e.op {
+		case "==":
+			return isNil
+		case "!=":
+			return !isNil
+		default:
+			return false
+		}
+	}
+
+	var cmp int
+	switch e.value.kind {
+	case literalNumber:
+		number, ok := new(big.Rat).SetString(value.String())
+		if !ok {
+			return false
+		}
+		cmp = number.Cmp(e.value.number)
+	case literalAddress:
+		address, ok := value.(cadence.Address)
+		if !ok {
+			return false
+		}
+		cmp = strings.Compare(flow.Address(address).Hex(), flow.HexToAddress(e.value.raw).Hex())
+	case literalBool:
+		b, ok := value.(cadence.Bool)
+		if !ok || (e.op != "==" && e.op != "!=") {
+			return false
+		}
+		cmp = strings.Compare(fmt.Sprint(bool(b)), e.value.raw)
+	default:
+		s, ok := value.(cadence.String)
+		if !ok {
+			return false
+		}
+		cmp = strings.Compare(string(s), e.value.raw)
+	}
+
+	switch e.op {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+
+	return false
+}
+
+type tokenKind int
+
+const (
+	tokenIdent tokenKind = iota
+	tokenLiteral
+	tokenOperator
+	tokenEnd
+)
+
+type token struct {
+	kind    tokenKind
+	value   string
+	literal literal
+}
+
+// tokenize splits the where expression into identifiers, literals and operators.
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case strings.ContainsRune("=!<>&|", r):
+			op := string(r)
+			if i+1 < len(runes) && strings.ContainsRune("=&|", runes[i+1]) {
+				op += string(runes[i+1])
+			}
+			switch op {
+			case "==", "!=", "<=", ">=", "&&", "||", "<", ">", "!":
+			default:
+				return nil, fmt.Errorf("invalid operator %s", op)
+			}
+			tokens = append(tokens, token{kind: tokenOperator, value: op})
+			i += len(op)
+		case r == '(' || r == ')':
+			tokens = append(tokens, token{kind: tokenOperator, value: string(r)})
+			i++
+		case r == '"' || r == '\'':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("unterminated string starting at position %d", i)
+			}
+			tokens = append(tokens, token{kind: tokenLiteral, literal: literal{kind: literalString, raw: string(runes[i+1 : end])}})
+			i = end + 1
+		default:
+			end := i
+			for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end]) || strings.ContainsRune("_.-", runes[end])) {
+				end++
+			}
+			if end == i {
+				return nil, fmt.Errorf("unexpected character %q at position %d", r, i)
+			}
+			tokens = append(tokens, wordToken(string(runes[i:end])))
+			i = end
+		}
+	}
+
+	return append(tokens, token{kind: tokenEnd}), nil
+}
+
+// wordToken classifies the word as a literal or a field identifier.
+func wordToken(word string) token {
+	switch {
+	case word == "true" || word == "false":
+		return token{kind: tokenLiteral, literal: literal{kind: literalBool, raw: word}}
+	case word == "nil":
+		return token{kind: tokenLiteral, literal: literal{kind: literalNil, raw: word}}
+	case strings.HasPrefix(word, "0x"):
+		return token{kind: tokenLiteral, literal: literal{kind: literalAddress, raw: word}}
+	}
+
+	if number, ok := new(big.Rat).SetString(word); ok {
+		return token{kind: tokenLiteral, literal: literal{kind: literalNumber, raw: word, number: number}}
+	}
+
+	return token{kind: tokenIdent, value: word}
+}
+
+type whereParser struct {
+	tokens []token
+	pos    int
+}
+
+// parseWhere parses the where expression, comparisons of event fields with literals can be
+// combined with &&, || and ! and grouped with parentheses.
+func parseWhere(input string) (whereExpr, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, nil
+	}
+
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, fmt.Errorf("invalid where expression: %w", err)
+	}
+
+	p := &whereParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid where expression: %w", err)
+	}
+	if p.peek().kind != tokenEnd {
+		return nil, fmt.Errorf("invalid where expression: unexpected %s", p.peek().String())
+	}
+
+	return expr, nil
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenLiteral:
+		return t.literal.raw
+	case tokenEnd:
+		return "end of expression"
+	default:
+		return t.value
+	}
+}
+
+func (p *whereParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *whereParser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEnd {
+		p.pos++
+	}
+	return t
+}
+
+func (p *whereParser) isOperator(op string) bool {
+	t := p.peek()
+	return t.kind == tokenOperator && t.value == op
+}
+
+func (p *whereParser) parseOr() (whereExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isOperator("||") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *whereParser) parseAnd() (whereExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isOperator("&&") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *whereParser) parseUnary() (whereExpr, error) {
+	if p.isOperator("!") {
+		p.next()
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{expr: expr}, nil
+	}
+
+	if p.isOperator("(") {
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.isOperator(")") {
+			return nil, fmt.Errorf("expected ) but got %s", p.peek().String())
+		}
+		p.next()
+		return expr, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *whereParser) parseComparison() (whereExpr, error) {
+	field := p.next()
+	if field.kind != tokenIdent {
+		return nil, fmt.Errorf("expected field name but got %s", field.String())
+	}
+
+	op := p.next()
+	if op.kind != tokenOperator || !strings.Contains(" == != > >= < <= ", fmt.Sprintf(" %s ", op.value)) {
+		return nil, fmt.Errorf("expected comparison operator after %s but got %s", field.value, op.String())
+	}
+
+	value := p.next()
+	switch value.kind {
+	case tokenLiteral:
+		return compareExpr{field: field.value, op: op.value, value: value.literal}, nil
+	case tokenIdent:
+		// unquoted words are compared as strings, for example type identifiers
+		return compareExpr{field: field.value, op: op.value, value: literal{kind: literalString, raw: value.value}}, nil
+	default:
+		return nil, fmt.Errorf("expected value after %s %s but got %s", field.value, op.value, value.String())
+	}
+}
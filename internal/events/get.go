@@ -31,11 +31,14 @@ import (
 )
 
 type flagsEvents struct {
-	Start   uint64 `flag:"start" info:"Start block height"`
-	End     uint64 `flag:"end" info:"End block height"`
-	Last    uint64 `default:"10" flag:"last" info:"Fetch number of blocks relative to the last block. Ignored if the start flag is set. Used as a default if no flags are provided"`
-	Workers int    `default:"10" flag:"workers" info:"Number of workers to use when fetching events in parallel"`
-	Batch   uint64 `default:"25" flag:"batch" info:"Number of blocks each worker will fetch"`
+	Start   uint64   `flag:"start" info:"Start block height"`
+	End     uint64   `flag:"end" info:"End block height"`
+	Last    uint64   `default:"10" flag:"last" info:"Fetch number of blocks relative to the last block. Ignored if the start flag is set. Used as a default if no flags are provided"`
+	Workers int      `default:"10" flag:"workers" info:"Number of workers to use when fetching events in parallel"`
+	Batch   uint64   `default:"25" flag:"batch" info:"Number of blocks each worker will fetch"`
+	Where   string   `default:"" flag:"where" info:"Only include events whose fields match the expression, for example 'to == 0x01cf0e2f2f715450 && amount > 10.0'"`
+	Fields  []string `default:"" flag:"fields" info:"Only include the provided event fields in the output"`
+	Export  string   `default:"" flag:"export" info:"Export the events to a .csv or .sqlite file with one table per event type, appending skips already exported events"`
 }
 
 var eventsFlags = flagsEvents{}
@@ -56,6 +59,18 @@ flow events get A.1654653399040a61.FlowToken.TokensDeposited --last 20 --network
 
 #if you want to fetch multiple event types that is done by sending in more events. Even fetching will be done in parallel.
 flow events get A.1654653399040a61.FlowToken.TokensDeposited A.1654653399040a61.FlowToken.TokensWithdrawn
+
+#reference the contract by the name used in the configuration, the address is resolved for the network
+flow events get FlowToken.TokensDeposited --network testnet
+
+#fetch all events declared by a contract using a wildcard
+flow events get 'A.1654653399040a61.FlowToken.*' --network mainnet
+
+#filter events by the field values and only output the selected fields
+flow events get A.1654653399040a61.FlowToken.TokensDeposited --where 'to == 0x01cf0e2f2f715450 && amount > 10.0' --fields amount,to
+
+#export the events to a SQLite database, running the command again with an overlapping range only adds new events
+flow events get A.1654653399040a61.FlowToken.TokensDeposited --start 11559500 --end 11569500 --export events.sqlite
 	`,
 	},
 	Flags: &eventsFlags,
@@ -64,12 +79,16 @@ flow events get A.1654653399040a61.FlowToken.TokensDeposited A.1654653399040a61.
 
 func get(
 	args []string,
-	_ command.GlobalFlags,
+	globalFlags command.GlobalFlags,
 	logger output.Logger,
-	_ flowkit.ReaderWriter,
+	rw flowkit.ReaderWriter,
 	flow flowkit.Services,
 ) (command.Result, error) {
-	var err error
+	filter, err := parseWhere(eventsFlags.Where)
+	if err != nil {
+		return nil, err
+	}
+
 	start := eventsFlags.Start
 	end := eventsFlags.End
 	last := eventsFlags.Last
@@ -93,12 +112,27 @@ func get(
 		return nil, fmt.Errorf("please provide either both start and end for range or only last flag")
 	}
 
+	state, err := loadState(globalFlags, rw)
+	if err != nil {
+		return nil, err
+	}
+
+	eventTypes, err := resolveEventTypes(state, flow.Network(), logger, args)
+	if err != nil {
+		return nil, err
+	}
+
 	logger.StartProgress("Fetching events...")
 	defer logger.StopProgress()
 
+	eventTypes, err = expandEventTypes(flow, eventTypes)
+	if err != nil {
+		return nil, err
+	}
+
 	events, err := flow.GetEvents(
 		context.Background(),
-		args,
+		eventTypes,
 		start,
 		end,
 		&flowkit.EventWorker{
@@ -110,5 +144,10 @@ func get(
 		return nil, err
 	}
 
+	events = filterEvents(events, filter, eventsFlags.Fields)
+	if eventsFlags.Export != "" {
+		return exportEvents(eventsFlags.Export, events, rw)
+	}
+
 	return &EventResult{BlockEvents: events}, nil
 }
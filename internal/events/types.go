@@ -0,0 +1,330 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/config"
+	"github.com/onflow/flowkit/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsTypes struct{}
+
+var typesFlags = flagsTypes{}
+
+var typesCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "types <address|contract name>",
+		Short: "List the events declared by the contracts of an account",
+		Example: `flow events types 0x1654653399040a61 --network mainnet
+
+flow events types FlowToken --network testnet`,
+		Args: cobra.ExactArgs(1),
+	},
+	Flags: &typesFlags,
+	Run:   types,
+}
+
+type eventField struct {
+	name     string
+	typeName string
+}
+
+// eventDeclaration is an event declared in a deployed contract.
+type eventDeclaration struct {
+	id     string
+	fields []eventField
+}
+
+func types(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	rw flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	address, contract, err := resolveContractReference(args[0], globalFlags, rw, flow.Network())
+	if err != nil {
+		return nil, err
+	}
+
+	logger.StartProgress(fmt.Sprintf("Fetching contracts of 0x%s...", address))
+	defer logger.StopProgress()
+
+	events, err := accountEvents(flow, address, contract)
+	if err != nil {
+		return nil, err
+	}
+
+	return &typesResult{events: events}, nil
+}
+
+// resolveContractReference returns the address of the contract with the name on the network using the contracts
+// deployed or aliased in the configuration, or parses the value as an address of which all contracts are included.
+func resolveContractReference(
+	value string,
+	globalFlags command.GlobalFlags,
+	rw flowkit.ReaderWriter,
+	network config.Network,
+) (flowsdk.Address, string, error) {
+	state, err := loadState(globalFlags, rw)
+	if err != nil {
+		return flowsdk.EmptyAddress, "", err
+	}
+
+	if state != nil {
+		if networks, ok := util.DeployedContracts(state)[value]; ok {
+			address, ok := networks[network.Name]
+			if !ok {
+				return flowsdk.EmptyAddress, "", fmt.Errorf("contract %s is not deployed or aliased on network %s", value, network.Name)
+			}
+			return flowsdk.HexToAddress(address), value, nil
+		}
+	}
+
+	address := flowsdk.HexToAddress(value)
+	if address == flowsdk.EmptyAddress {
+		return flowsdk.EmptyAddress, "", fmt.Errorf("invalid address or contract name: %s", value)
+	}
+
+	return address, "", nil
+}
+
+// loadState loads the configuration if present, contracts can then be referenced by name.
+func loadState(globalFlags command.GlobalFlags, rw flowkit.ReaderWriter) (*flowkit.State, error) {
+	state, err := flowkit.Load(globalFlags.ConfigPaths, rw)
+	if err != nil && !errors.Is(err, config.ErrDoesNotExist) {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// resolveEventTypes qualifies the event types referencing a contract by name, such as FlowToken.TokensDeposited,
+// with the address the contract is deployed or aliased to on the network in the configuration.
+//
+// Event types of contracts not found in the configuration, such as flow.AccountCreated, are left unchanged.
+func resolveEventTypes(
+	state *flowkit.State,
+	network config.Network,
+	logger output.Logger,
+	eventTypes []string,
+) ([]string, error) {
+	if state == nil {
+		return eventTypes, nil
+	}
+
+	contracts := util.DeployedContracts(state)
+	resolved := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		contract, event, found := strings.Cut(eventType, ".")
+		networks, ok := contracts[contract]
+		if !found || strings.Contains(event, ".") || !ok {
+			resolved = append(resolved, eventType)
+			continue
+		}
+
+		address, ok := networks[network.Name]
+		if !ok {
+			return nil, fmt.Errorf("contract %s is not deployed or aliased on network %s", contract, network.Name)
+		}
+
+		qualified := fmt.Sprintf("A.%s.%s.%s", flowsdk.HexToAddress(address).Hex(), contract, event)
+		logger.Info(fmt.Sprintf("Resolved event type %s to %s", eventType, qualified))
+		resolved = append(resolved, qualified)
+	}
+
+	return resolved, nil
+}
+
+// accountEvents returns the events declared in the contracts of the account, or only in the
+// contract with the provided name.
+func accountEvents(flow flowkit.Services, address flowsdk.Address, contract string) ([]eventDeclaration, error) {
+	account, err := flow.GetAccount(context.Background(), address)
+	if err != nil {
+		return nil, err
+	}
+
+	if contract != "" {
+		if _, ok := account.Contracts[contract]; !ok {
+			return nil, fmt.Errorf("contract %s is not deployed to account 0x%s", contract, address)
+		}
+	}
+
+	names := make([]string, 0, len(account.Contracts))
+	for name := range account.Contracts {
+		if contract == "" || name == contract {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	events := make([]eventDeclaration, 0)
+	for _, name := range names {
+		declared, err := contractEvents(address, account.Contracts[name])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse contract %s: %w", name, err)
+		}
+		events = append(events, declared...)
+	}
+
+	return events, nil
+}
+
+// contractEvents parses the contract code and returns the events declared in the contract and contract interfaces.
+func contractEvents(address flowsdk.Address, code []byte) ([]eventDeclaration, error) {
+	program, err := parser.ParseProgram(nil, code, parser.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]eventDeclaration, 0)
+	addEvents := func(contract string, members *ast.Members) {
+		for _, composite := range members.Composites() {
+			if composite.CompositeKind != common.CompositeKindEvent {
+				continue
+			}
+
+			event := eventDeclaration{
+				id: fmt.Sprintf("A.%s.%s.%s", address.Hex(), contract, composite.Identifier.Identifier),
+			}
+			for _, initializer := range composite.Members.Initializers() {
+				for _, parameter := range initializer.FunctionDeclaration.ParameterList.Parameters {
+					event.fields = append(event.fields, eventField{
+						name:     parameter.Identifier.Identifier,
+						typeName: parameter.TypeAnnotation.Type.String(),
+					})
+				}
+			}
+			events = append(events, event)
+		}
+	}
+
+	for _, declaration := range program.CompositeDeclarations() {
+		addEvents(declaration.Identifier.Identifier, declaration.Members)
+	}
+	for _, declaration := range program.InterfaceDeclarations() {
+		addEvents(declaration.Identifier.Identifier, declaration.Members)
+	}
+
+	return events, nil
+}
+
+// expandEventTypes replaces the event types ending with a wildcard, such as A.1654653399040a61.FlowToken.*,
+// with all the events declared by the contract.
+func expandEventTypes(flow flowkit.Services, eventTypes []string) ([]string, error) {
+	expanded := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		if !strings.HasSuffix(eventType, ".*") {
+			expanded = append(expanded, eventType)
+			continue
+		}
+
+		parts := strings.Split(strings.TrimSuffix(eventType, ".*"), ".")
+		if len(parts) != 3 || parts[0] != "A" {
+			return nil, fmt.Errorf("invalid event type %s, wildcards must be in the A.<address>.<contract>.* format", eventType)
+		}
+
+		events, err := accountEvents(flow, flowsdk.HexToAddress(parts[1]), parts[2])
+		if err != nil {
+			return nil, err
+		}
+		if len(events) == 0 {
+			return nil, fmt.Errorf("contract %s does not declare any events", parts[2])
+		}
+
+		for _, event := range events {
+			expanded = append(expanded, event.id)
+		}
+	}
+
+	return expanded, nil
+}
+
+type typesResult struct {
+	events []eventDeclaration
+}
+
+func (r *typesResult) JSON() any {
+	events := make([]any, 0, len(r.events))
+	for _, event := range r.events {
+		fields := make([]any, 0, len(event.fields))
+		for _, field := range event.fields {
+			fields = append(fields, map[string]any{
+				"name": field.name,
+				"type": field.typeName,
+			})
+		}
+
+		events = append(events, map[string]any{
+			"type":   event.id,
+			"fields": fields,
+		})
+	}
+
+	return events
+}
+
+func (r *typesResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	if len(r.events) == 0 {
+		_, _ = fmt.Fprintf(writer, "No events declared.\n")
+	}
+
+	for i, event := range r.events {
+		if i > 0 {
+			_, _ = fmt.Fprintf(writer, "\n")
+		}
+		_, _ = fmt.Fprintf(writer, "Type\t%s\n", event.id)
+		_, _ = fmt.Fprintf(writer, "Fields\n")
+		for _, field := range event.fields {
+			_, _ = fmt.Fprintf(writer, "\t\t- %s (%s)\n", field.name, field.typeName)
+		}
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *typesResult) Oneliner() string {
+	events := make([]string, 0, len(r.events))
+	for _, event := range r.events {
+		events = append(events, event.id)
+	}
+
+	return strings.Join(events, ", ")
+}
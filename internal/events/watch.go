@@ -0,0 +1,285 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"time"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsWatch struct {
+	Start      uint64 `flag:"start" info:"Block height to start watching from, defaults to the latest sealed block"`
+	Interval   string `default:"2s" flag:"interval" info:"Interval between polls for new sealed blocks"`
+	Batch      uint64 `default:"250" flag:"batch" info:"Maximum number of blocks fetched in a single poll"`
+	Checkpoint string `default:"" flag:"checkpoint" info:"File storing the last processed block height, watching resumes after the stored height when the file exists"`
+}
+
+var watchFlags = flagsWatch{}
+
+// watchStartChanged is set when the start flag is provided, since 0 is a valid start height.
+var watchStartChanged bool
+
+var watchCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "watch <event_name>",
+		Short: "Watch events as new blocks are sealed",
+		Args:  cobra.MinimumNArgs(1),
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			watchStartChanged = cmd.Flags().Changed("start")
+		},
+		Example: `#follow the chain head and print new events
+flow events watch A.1654653399040a61.FlowToken.TokensDeposited --network mainnet
+
+#output events as JSON lines and resume from the checkpoint after restarts
+flow events watch A.1654653399040a61.FlowToken.TokensDeposited --checkpoint events.checkpoint --output json`,
+	},
+	Flags: &watchFlags,
+	Run:   watch,
+}
+
+// checkpoint is the progress of the watcher stored in the checkpoint file.
+type checkpoint struct {
+	Height uint64 `json:"height"`
+}
+
+func watch(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	rw flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	// events are written as they are received, so the result can't be saved or filtered
+	if globalFlags.Save != "" || globalFlags.Filter != "" {
+		return nil, fmt.Errorf("save and filter flags are not supported when watching events, redirect the output instead")
+	}
+
+	interval, err := time.ParseDuration(watchFlags.Interval)
+	if err != nil || interval <= 0 {
+		return nil, fmt.Errorf("invalid interval %s, must be a positive duration such as 2s", watchFlags.Interval)
+	}
+	if watchFlags.Batch == 0 {
+		return nil, fmt.Errorf("batch must be a positive number")
+	}
+
+	state, err := loadState(globalFlags, rw)
+	if err != nil {
+		return nil, err
+	}
+
+	eventTypes, err := resolveEventTypes(state, flow.Network(), logger, args)
+	if err != nil {
+		return nil, err
+	}
+
+	eventTypes, err = expandEventTypes(flow, eventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	next, err := watchStartHeight(ctx, rw, flow)
+	if err != nil {
+		return nil, err
+	}
+
+	emit := writeEventsText
+	if globalFlags.Format == command.FormatJSON {
+		emit = writeEventsJSONL
+	}
+
+	err = watchEvents(ctx, flow, eventTypes, next, interval, func(events []flowsdk.BlockEvents, height uint64) error {
+		if err := emit(os.Stdout, events); err != nil {
+			return err
+		}
+		return saveCheckpoint(rw, height)
+	})
+	if err != nil && !errors.Is(err, context.Canceled) {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// watchStartHeight returns the height after the checkpoint, the start flag height or the latest sealed height.
+func watchStartHeight(ctx context.Context, rw flowkit.ReaderWriter, flow flowkit.Services) (uint64, error) {
+	if watchFlags.Checkpoint != "" {
+		data, err := rw.ReadFile(watchFlags.Checkpoint)
+		if err == nil {
+			var c checkpoint
+			if err := json.Unmarshal(data, &c); err != nil {
+				return 0, fmt.Errorf("invalid checkpoint file %s: %w", watchFlags.Checkpoint, err)
+			}
+			return c.Height + 1, nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return 0, fmt.Errorf("failed to read checkpoint file %s: %w", watchFlags.Checkpoint, err)
+		}
+	}
+
+	if watchStartChanged {
+		return watchFlags.Start, nil
+	}
+
+	latest, err := flow.GetBlock(ctx, flowkit.BlockQuery{Latest: true})
+	if err != nil {
+		return 0, err
+	}
+
+	return latest.Height, nil
+}
+
+func saveCheckpoint(rw flowkit.ReaderWriter, height uint64) error {
+	if watchFlags.Checkpoint == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(checkpoint{Height: height})
+	if err != nil {
+		return err
+	}
+
+	return rw.WriteFile(watchFlags.Checkpoint, data, os.FileMode(0644))
+}
+
+// watchEvents polls the sealed blocks starting at the height and calls emit with the events of each
+// fetched range and the last height of the range, until the context is cancelled.
+//
+// Every height is fetched exactly once, so no events are missed or duplicated between the polls.
+func watchEvents(
+	ctx context.Context,
+	flow flowkit.Services,
+	types []string,
+	next uint64,
+	interval time.Duration,
+	emit func(events []flowsdk.BlockEvents, height uint64) error,
+) error {
+	for {
+		latest, err := flow.GetBlock(ctx, flowkit.BlockQuery{Latest: true})
+		if err != nil {
+			return err
+		}
+
+		if latest.Height >= next {
+			end := latest.Height
+			if end-next+1 > watchFlags.Batch {
+				end = next + watchFlags.Batch - 1
+			}
+
+			events, err := flow.GetEvents(ctx, types, next, end, &flowkit.EventWorker{
+				Count:           1,
+				BlocksPerWorker: watchFlags.Batch,
+			})
+			if err != nil {
+				return err
+			}
+
+			if err := emit(sortBlockEvents(events), end); err != nil {
+				return err
+			}
+
+			next = end + 1
+			if end < latest.Height {
+				continue // catch up without waiting
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// sortBlockEvents merges the events of the different event types fetched for the same block
+// and orders the blocks by height and the events in the order they were emitted.
+func sortBlockEvents(blockEvents []flowsdk.BlockEvents) []flowsdk.BlockEvents {
+	merged := make(map[uint64]*flowsdk.BlockEvents)
+	for _, blockEvent := range blockEvents {
+		if len(blockEvent.Events) == 0 {
+			continue
+		}
+
+		if _, ok := merged[blockEvent.Height]; !ok {
+			merged[blockEvent.Height] = &flowsdk.BlockEvents{
+				BlockID:        blockEvent.BlockID,
+				Height:         blockEvent.Height,
+				BlockTimestamp: blockEvent.BlockTimestamp,
+			}
+		}
+		merged[blockEvent.Height].Events = append(merged[blockEvent.Height].Events, blockEvent.Events...)
+	}
+
+	result := make([]flowsdk.BlockEvents, 0, len(merged))
+	for _, blockEvent := range merged {
+		sort.SliceStable(blockEvent.Events, func(i, j int) bool {
+			a, b := blockEvent.Events[i], blockEvent.Events[j]
+			if a.TransactionIndex != b.TransactionIndex {
+				return a.TransactionIndex < b.TransactionIndex
+			}
+			return a.EventIndex < b.EventIndex
+		})
+		result = append(result, *blockEvent)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Height < result[j].Height
+	})
+
+	return result
+}
+
+func writeEventsText(w io.Writer, events []flowsdk.BlockEvents) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	_, err := fmt.Fprint(w, (&EventResult{BlockEvents: events}).String())
+	return err
+}
+
+// writeEventsJSONL writes every event as a JSON object on a separate line.
+func writeEventsJSONL(w io.Writer, events []flowsdk.BlockEvents) error {
+	encoder := json.NewEncoder(w)
+	for _, event := range (&EventResult{BlockEvents: events}).JSON().([]any) {
+		if err := encoder.Encode(event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keybackend
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+func init() {
+	register("external", newExternalBackend)
+}
+
+// externalBackend delegates signing to an external signer command.
+//
+// The command receives a JSON request on the standard input:
+//
+//	{ "digest": "<hex>", "publicKey": "<hex>", "sigAlgo": "ECDSA_P256", "hashAlgo": "SHA3_256" }
+//
+// and must write the JSON response with the raw signature of the digest to the standard output:
+//
+//	{ "signature": "<hex>" }
+type externalBackend struct {
+	Command   string   `json:"command"`
+	Args      []string `json:"args"`
+	PublicKey string   `json:"publicKey"`
+}
+
+type externalSignRequest struct {
+	Digest    string `json:"digest"`
+	PublicKey string `json:"publicKey"`
+	SigAlgo   string `json:"sigAlgo"`
+	HashAlgo  string `json:"hashAlgo"`
+}
+
+type externalSignResponse struct {
+	Signature string `json:"signature"`
+}
+
+func newExternalBackend(descriptor []byte) (Backend, error) {
+	backend := &externalBackend{}
+	if err := json.Unmarshal(descriptor, backend); err != nil {
+		return nil, fmt.Errorf("invalid external key backend descriptor: %w", err)
+	}
+
+	if backend.Command == "" {
+		return nil, fmt.Errorf("external signer command must be provided")
+	}
+
+	return backend, nil
+}
+
+func (e *externalBackend) Signer(
+	ctx context.Context,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+) (crypto.Signer, error) {
+	publicKey, err := decodePublicKey(sigAlgo, e.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return newDigestSigner(publicKey, hashAlgo, func(digest []byte) ([]byte, error) {
+		request, err := json.Marshal(externalSignRequest{
+			Digest:    hex.EncodeToString(digest),
+			PublicKey: strings.TrimPrefix(publicKey.String(), "0x"),
+			SigAlgo:   sigAlgo.String(),
+			HashAlgo:  hashAlgo.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		output, err := runCommand(ctx, request, e.Command, e.Args...)
+		if err != nil {
+			return nil, err
+		}
+
+		var response externalSignResponse
+		if err := json.Unmarshal(output, &response); err != nil {
+			return nil, fmt.Errorf("invalid external signer response: %w", err)
+		}
+
+		signature, err := hex.DecodeString(strings.TrimPrefix(response.Signature, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid external signer signature: %w", err)
+		}
+
+		return signature, nil
+	})
+}
@@ -0,0 +1,239 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package keybackend implements account keys which sign without the private key being stored
+// in plaintext in the project.
+//
+// An account uses a key backend by referencing a key file in the configuration, the same way as
+// a regular file key, but the file contains a JSON descriptor of the backend instead of the hex
+// encoded private key, for example:
+//
+//	{ "backend": "pkcs11", "module": "/usr/lib/softhsm/libsofthsm2.so", "id": "01", "publicKey": "..." }
+//
+// The configuration format is not changed so the file keys are replaced with backend keys after
+// the configuration is loaded.
+package keybackend
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/accounts"
+	"github.com/onflow/flowkit/config"
+)
+
+// Backend creates signers for a private key managed outside the project.
+type Backend interface {
+	// Signer returns the signer for the key using the provided algorithms.
+	Signer(ctx context.Context, sigAlgo crypto.SignatureAlgorithm, hashAlgo crypto.HashAlgorithm) (crypto.Signer, error)
+}
+
+// factories contains the supported backends by the backend name used in the descriptor.
+var factories = map[string]func(descriptor []byte) (Backend, error){}
+
+func register(name string, factory func(descriptor []byte) (Backend, error)) {
+	factories[name] = factory
+}
+
+// Names returns the names of the supported backends.
+func Names() []string {
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+type descriptorHeader struct {
+	Backend string `json:"backend"`
+}
+
+// IsDescriptor returns true if the key file content is a backend descriptor instead of a private key.
+func IsDescriptor(data []byte) bool {
+	var header descriptorHeader
+	if err := json.Unmarshal(data, &header); err != nil {
+		return false
+	}
+
+	return header.Backend != ""
+}
+
+// Parse creates the backend described by the key file content.
+func Parse(data []byte) (Backend, error) {
+	var header descriptorHeader
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("invalid key backend descriptor: %w", err)
+	}
+
+	factory, ok := factories[header.Backend]
+	if !ok {
+		return nil, fmt.Errorf(
+			"unsupported key backend %s, supported backends: %s",
+			header.Backend,
+			strings.Join(Names(), ", "),
+		)
+	}
+
+	return factory(data)
+}
+
+// ResolveAccountKeys replaces the file keys of the accounts in the state which reference
+// a backend descriptor with the backend keys.
+//
+// The descriptors are only parsed once a key is used to sign, so an invalid descriptor only
+// fails the commands using that account.
+func ResolveAccountKeys(state *flowkit.State) {
+	for i, account := range *state.Accounts() {
+		if account.Key == nil || account.Key.Type() != config.KeyTypeFile {
+			continue
+		}
+		if _, ok := account.Key.(*Key); ok {
+			continue
+		}
+
+		location := account.Key.ToConfig().Location
+		data, err := state.ReaderWriter().ReadFile(location)
+		if err != nil || !IsDescriptor(data) {
+			continue // regular key files and missing files are handled by the file key
+		}
+
+		(*state.Accounts())[i].Key = &Key{
+			location:   location,
+			index:      account.Key.Index(),
+			sigAlgo:    account.Key.SigAlgo(),
+			hashAlgo:   account.Key.HashAlgo(),
+			descriptor: data,
+		}
+	}
+}
+
+var _ accounts.Key = &Key{}
+
+// Key is an account key which signs using a key backend.
+//
+// The key is stored in the configuration as a file key referencing the backend descriptor file.
+type Key struct {
+	location   string
+	index      int
+	sigAlgo    crypto.SignatureAlgorithm
+	hashAlgo   crypto.HashAlgorithm
+	descriptor []byte
+	backend    Backend
+}
+
+func NewKey(
+	location string,
+	index int,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+	backend Backend,
+) *Key {
+	return &Key{
+		location: location,
+		index:    index,
+		sigAlgo:  sigAlgo,
+		hashAlgo: hashAlgo,
+		backend:  backend,
+	}
+}
+
+func (k *Key) Type() config.KeyType {
+	return config.KeyTypeFile
+}
+
+func (k *Key) Index() int {
+	return k.index
+}
+
+func (k *Key) SigAlgo() crypto.SignatureAlgorithm {
+	return k.sigAlgo
+}
+
+func (k *Key) HashAlgo() crypto.HashAlgorithm {
+	return k.hashAlgo
+}
+
+func (k *Key) Signer(ctx context.Context) (crypto.Signer, error) {
+	if k.backend == nil {
+		backend, err := Parse(k.descriptor)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key backend stored at %s: %w", k.location, err)
+		}
+		k.backend = backend
+	}
+
+	return k.backend.Signer(ctx, k.sigAlgo, k.hashAlgo)
+}
+
+func (k *Key) ToConfig() config.AccountKey {
+	return config.AccountKey{
+		Type:     config.KeyTypeFile,
+		Index:    k.index,
+		SigAlgo:  k.sigAlgo,
+		HashAlgo: k.hashAlgo,
+		Location: k.location,
+	}
+}
+
+func (k *Key) Validate() error {
+	if !crypto.CompatibleAlgorithms(k.sigAlgo, k.hashAlgo) {
+		return fmt.Errorf("signature algorithm %s and hashing algorithm %s are incompatible", k.sigAlgo, k.hashAlgo)
+	}
+
+	return nil
+}
+
+func (k *Key) PrivateKey() (*crypto.PrivateKey, error) {
+	return nil, fmt.Errorf("private key is not accessible for the key backend stored at %s", k.location)
+}
+
+// runCommand runs the command with the input and returns the standard output, it is replaced in tests.
+var runCommand = func(ctx context.Context, input []byte, name string, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s failed: %w: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// secretFromEnv returns the value of the environment variable or an error if it is not set.
+func secretFromEnv(name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,239 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keybackend
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flowkit/accounts"
+	"github.com/onflow/flowkit/config"
+
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func testPublicKey(t *testing.T) crypto.PublicKey {
+	privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, []byte(strings.Repeat("k", crypto.MinSeedLength)))
+	require.NoError(t, err)
+	return privateKey.PublicKey()
+}
+
+func Test_ResolveAccountKeys(t *testing.T) {
+	_, state, rw := util.TestMocks(t)
+	publicKey := testPublicKey(t)
+
+	descriptor := fmt.Sprintf(`{"backend": "external", "command": "signer", "publicKey": "%s"}`, publicKey.String())
+	require.NoError(t, rw.WriteFile("alice.key.json", []byte(descriptor), 0644))
+	state.Accounts().AddOrUpdate(&accounts.Account{
+		Name:    "alice",
+		Address: flow.HexToAddress("0x01"),
+		Key:     accounts.NewFileKey("alice.key.json", 1, crypto.ECDSA_P256, crypto.SHA3_256, rw),
+	})
+
+	require.NoError(t, rw.WriteFile("bob.key.json", []byte(`{"backend": "unknown"}`), 0644))
+	state.Accounts().AddOrUpdate(&accounts.Account{
+		Name:    "bob",
+		Address: flow.HexToAddress("0x02"),
+		Key:     accounts.NewFileKey("bob.key.json", 0, crypto.ECDSA_P256, crypto.SHA3_256, rw),
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		ResolveAccountKeys(state)
+
+		alice, err := state.Accounts().ByName("alice")
+		require.NoError(t, err)
+		key, ok := alice.Key.(*Key)
+		require.True(t, ok)
+		assert.Equal(t, 1, key.Index())
+		assert.Equal(t, config.AccountKey{
+			Type:     config.KeyTypeFile,
+			Index:    1,
+			SigAlgo:  crypto.ECDSA_P256,
+			HashAlgo: crypto.SHA3_256,
+			Location: "alice.key.json",
+		}, key.ToConfig())
+
+		emulator, err := state.Accounts().ByName("emulator-account")
+		require.NoError(t, err)
+		_, ok = emulator.Key.(*Key)
+		assert.False(t, ok)
+	})
+
+	t.Run("Fail invalid backend on sign", func(t *testing.T) {
+		ResolveAccountKeys(state)
+
+		bob, err := state.Accounts().ByName("bob")
+		require.NoError(t, err)
+		_, err = bob.Key.Signer(context.Background())
+		assert.EqualError(t, err, "invalid key backend stored at bob.key.json: unsupported key backend unknown, supported backends: external, keychain, keystore, pkcs11")
+	})
+
+	t.Run("Fail unsupported backend", func(t *testing.T) {
+		_, err := Parse([]byte(`{"backend": "unknown"}`))
+		assert.EqualError(t, err, "unsupported key backend unknown, supported backends: external, keychain, keystore, pkcs11")
+	})
+
+	t.Run("Fail pkcs11 missing module", func(t *testing.T) {
+		_, err := Parse([]byte(`{"backend": "pkcs11", "id": "01"}`))
+		assert.EqualError(t, err, "pkcs11 module path must be provided")
+	})
+}
+
+func Test_ExternalSigner(t *testing.T) {
+	publicKey := testPublicKey(t)
+	backend, err := Parse([]byte(fmt.Sprintf(
+		`{"backend": "external", "command": "signer", "args": ["--key", "alice"], "publicKey": "%s"}`,
+		publicKey.String(),
+	)))
+	require.NoError(t, err)
+
+	runCommand = func(_ context.Context, input []byte, name string, args ...string) ([]byte, error) {
+		assert.Equal(t, "signer", name)
+		assert.Equal(t, []string{"--key", "alice"}, args)
+
+		var request externalSignRequest
+		require.NoError(t, json.Unmarshal(input, &request))
+		assert.Equal(t, "SHA3_256", request.HashAlgo)
+		assert.Len(t, request.Digest, 64)
+
+		return []byte(`{"signature": "0a0b"}`), nil
+	}
+
+	signer, err := NewKey("alice.key.json", 0, crypto.ECDSA_P256, crypto.SHA3_256, backend).Signer(context.Background())
+	require.NoError(t, err)
+	assert.True(t, publicKey.Equals(signer.PublicKey()))
+
+	signature, err := signer.Sign([]byte("message"))
+	require.NoError(t, err)
+	assert.Equal(t, "0a0b", hex.EncodeToString(signature))
+}
+
+func Test_PKCS11Signer(t *testing.T) {
+	publicKey := testPublicKey(t)
+	backend, err := Parse([]byte(fmt.Sprintf(
+		`{"backend": "pkcs11", "module": "softhsm.so", "id": "01", "publicKey": "%s"}`,
+		publicKey.String(),
+	)))
+	require.NoError(t, err)
+	t.Setenv("FLOW_PKCS11_PIN", "1234")
+
+	runCommand = func(_ context.Context, input []byte, name string, args ...string) ([]byte, error) {
+		assert.Equal(t, "pkcs11-tool", name)
+		assert.Equal(t, []string{
+			"--module", "softhsm.so", "--pin", "env:FLOW_PKCS11_PIN", "--sign", "--mechanism", "ECDSA", "--id", "01",
+		}, args)
+		assert.NotContains(t, strings.Join(args, " "), "1234")
+		assert.Len(t, input, 32)
+
+		return []byte{0x0a, 0x0b}, nil
+	}
+
+	signer, err := NewKey("alice.key.json", 0, crypto.ECDSA_P256, crypto.SHA3_256, backend).Signer(context.Background())
+	require.NoError(t, err)
+
+	signature, err := signer.Sign([]byte("message"))
+	require.NoError(t, err)
+	assert.Equal(t, "0a0b", hex.EncodeToString(signature))
+}
+
+func Test_Keystore(t *testing.T) {
+	scryptN = 1 << 10 // keep the key derivation fast in tests
+
+	privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_secp256k1, []byte(strings.Repeat("k", crypto.MinSeedLength)))
+	require.NoError(t, err)
+
+	t.Run("Success", func(t *testing.T) {
+		data, err := Encrypt(privateKey, "secret", PassphraseEnv)
+		require.NoError(t, err)
+		assert.True(t, IsDescriptor(data))
+		assert.NotContains(t, string(data), hex.EncodeToString(privateKey.Encode()))
+
+		var keystore Keystore
+		require.NoError(t, json.Unmarshal(data, &keystore))
+		assert.Equal(t, "ECDSA_secp256k1", keystore.SigAlgo)
+		assert.Equal(t, "", keystore.PassphraseEnv)
+		assert.Equal(t, strings.TrimPrefix(privateKey.PublicKey().String(), "0x"), keystore.PublicKey)
+
+		decrypted, err := Decrypt(data, "secret")
+		require.NoError(t, err)
+		assert.True(t, privateKey.Equals(decrypted))
+	})
+
+	t.Run("Success signer with passphrase from environment", func(t *testing.T) {
+		data, err := Encrypt(privateKey, "secret", "FLOW_TEST_PASSPHRASE")
+		require.NoError(t, err)
+		t.Setenv("FLOW_TEST_PASSPHRASE", "secret")
+
+		backend, err := Parse(data)
+		require.NoError(t, err)
+
+		signer, err := backend.Signer(context.Background(), crypto.ECDSA_secp256k1, crypto.SHA3_256)
+		require.NoError(t, err)
+		assert.True(t, privateKey.PublicKey().Equals(signer.PublicKey()))
+	})
+
+	t.Run("Fail wrong passphrase", func(t *testing.T) {
+		data, err := Encrypt(privateKey, "secret", PassphraseEnv)
+		require.NoError(t, err)
+
+		_, err = Decrypt(data, "wrong")
+		assert.EqualError(t, err, "could not decrypt the keystore, the passphrase is incorrect")
+	})
+
+	t.Run("Fail unsupported version", func(t *testing.T) {
+		_, err := Decrypt([]byte(`{"backend": "keystore", "version": 2}`), "secret")
+		assert.EqualError(t, err, "unsupported keystore version 2")
+	})
+	t.Run("Fail invalid scrypt parameters", func(t *testing.T) {
+		data, err := Encrypt(privateKey, "secret", PassphraseEnv)
+		require.NoError(t, err)
+
+		decrypt := func(update func(params *keystoreKDFParams)) error {
+			var keystore Keystore
+			require.NoError(t, json.Unmarshal(data, &keystore))
+			update(&keystore.Crypto.KDFParams)
+			updated, err := json.Marshal(keystore)
+			require.NoError(t, err)
+
+			_, err = Decrypt(updated, "secret")
+			return err
+		}
+
+		err = decrypt(func(params *keystoreKDFParams) { params.DKLen = 16 })
+		assert.EqualError(t, err, "unsupported keystore key length 16, must be 32")
+
+		err = decrypt(func(params *keystoreKDFParams) { params.N = 1000 })
+		assert.EqualError(t, err, "invalid keystore scrypt parameter N 1000, must be a power of two")
+
+		err = decrypt(func(params *keystoreKDFParams) { params.P = 1 << 20 })
+		assert.EqualError(t, err, "invalid keystore scrypt parameters r 8 and p 1048576")
+
+		err = decrypt(func(params *keystoreKDFParams) { params.N = 1 << 30 })
+		assert.EqualError(t, err, "keystore scrypt parameters N 1073741824 and r 8 exceed the memory limit of 256 MiB")
+	})
+}
@@ -0,0 +1,87 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keybackend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+func init() {
+	register("keychain", newKeychainBackend)
+}
+
+// keychainBackend loads the hex encoded private key from the operating system keychain,
+// using the security tool on macOS and the secret-tool of libsecret on Linux.
+type keychainBackend struct {
+	Service string `json:"service"`
+	Account string `json:"account"`
+}
+
+func newKeychainBackend(descriptor []byte) (Backend, error) {
+	backend := &keychainBackend{Service: "flow-cli"}
+	if err := json.Unmarshal(descriptor, backend); err != nil {
+		return nil, fmt.Errorf("invalid keychain key backend descriptor: %w", err)
+	}
+
+	if backend.Account == "" {
+		return nil, fmt.Errorf("keychain account must be provided")
+	}
+
+	return backend, nil
+}
+
+func (k *keychainBackend) Signer(
+	ctx context.Context,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+) (crypto.Signer, error) {
+	var (
+		secret []byte
+		err    error
+	)
+	switch runtime.GOOS {
+	case "darwin":
+		secret, err = runCommand(ctx, nil, "security", "find-generic-password", "-s", k.Service, "-a", k.Account, "-w")
+	case "linux":
+		secret, err = runCommand(ctx, nil, "secret-tool", "lookup", "service", k.Service, "account", k.Account)
+	default:
+		return nil, fmt.Errorf("keychain key backend is not supported on %s", runtime.GOOS)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the key from the keychain: %w", err)
+	}
+
+	privateKey, err := crypto.DecodePrivateKeyHex(sigAlgo, strings.TrimPrefix(strings.TrimSpace(string(secret)), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key stored in the keychain: %w", err)
+	}
+
+	signer, err := crypto.NewInMemorySigner(privateKey, hashAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &signer, nil
+}
@@ -0,0 +1,315 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keybackend
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/onflow/flow-cli/internal/prompt"
+)
+
+func init() {
+	register(KeystoreBackend, newKeystoreBackend)
+}
+
+const (
+	// KeystoreBackend is the name of the encrypted keystore backend.
+	KeystoreBackend = "keystore"
+
+	// PassphraseEnv is the default environment variable the keystore passphrase is read from.
+	PassphraseEnv = "FLOW_KEYSTORE_PASSPHRASE"
+
+	keystoreVersion = 1
+	keystoreCipher  = "aes-256-gcm"
+	keystoreKDF     = "scrypt"
+)
+
+// scrypt parameters, the same as the standard parameters of the Ethereum keystore.
+var (
+	scryptN = 1 << 18
+	scryptR = 8
+	scryptP = 1
+)
+
+// limits of the scrypt parameters read from a keystore, which prevent a crafted keystore from using
+// an excessive amount of memory or time when the key is derived.
+const (
+	scryptMaxMemory = 256 << 20 // memory used by scrypt is 128 * N * R bytes
+	scryptMaxP      = 16
+	keystoreKeyLen  = 32 // AES-256 key length
+)
+
+// Keystore is the private key encrypted with AES-GCM using the key derived from the passphrase with scrypt,
+// the format follows the Ethereum keystore.
+type Keystore struct {
+	Backend       string         `json:"backend"`
+	Version       int            `json:"version"`
+	SigAlgo       string         `json:"sigAlgo"`
+	PublicKey     string         `json:"publicKey"`
+	PassphraseEnv string         `json:"passphraseEnv,omitempty"`
+	Crypto        keystoreCrypto `json:"crypto"`
+}
+
+type keystoreCrypto struct {
+	Cipher       string               `json:"cipher"`
+	CipherText   string               `json:"ciphertext"`
+	CipherParams keystoreCipherParams `json:"cipherparams"`
+	KDF          string               `json:"kdf"`
+	KDFParams    keystoreKDFParams    `json:"kdfparams"`
+}
+
+type keystoreCipherParams struct {
+	Nonce string `json:"nonce"`
+}
+
+type keystoreKDFParams struct {
+	N     int    `json:"n"`
+	R     int    `json:"r"`
+	P     int    `json:"p"`
+	DKLen int    `json:"dklen"`
+	Salt  string `json:"salt"`
+}
+
+// Encrypt encrypts the private key with the passphrase and returns the keystore file content,
+// the passphrase environment variable is stored in the keystore if it differs from the default one.
+func Encrypt(privateKey crypto.PrivateKey, passphrase string, passphraseEnv string) ([]byte, error) {
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	params := keystoreKDFParams{N: scryptN, R: scryptR, P: scryptP, DKLen: keystoreKeyLen, Salt: hex.EncodeToString(salt)}
+	gcm, err := keystoreAEAD(passphrase, params)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	cipherText := gcm.Seal(nil, nonce, privateKey.Encode(), nil)
+
+	if passphraseEnv == PassphraseEnv {
+		passphraseEnv = ""
+	}
+
+	return json.MarshalIndent(Keystore{
+		Backend:       KeystoreBackend,
+		Version:       keystoreVersion,
+		SigAlgo:       privateKey.Algorithm().String(),
+		PublicKey:     strings.TrimPrefix(privateKey.PublicKey().String(), "0x"),
+		PassphraseEnv: passphraseEnv,
+		Crypto: keystoreCrypto{
+			Cipher:       keystoreCipher,
+			CipherText:   hex.EncodeToString(cipherText),
+			CipherParams: keystoreCipherParams{Nonce: hex.EncodeToString(nonce)},
+			KDF:          keystoreKDF,
+			KDFParams:    params,
+		},
+	}, "", "\t")
+}
+
+// Decrypt decrypts the private key from the keystore file content with the passphrase.
+func Decrypt(data []byte, passphrase string) (crypto.PrivateKey, error) {
+	keystore, err := parseKeystore(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return keystore.decrypt(passphrase)
+}
+
+// Unlock decrypts the private key from the keystore file content reading the passphrase from
+// the environment variable of the keystore or prompting for it.
+func Unlock(data []byte) (crypto.PrivateKey, error) {
+	keystore, err := parseKeystore(data)
+	if err != nil {
+		return nil, err
+	}
+
+	passphrase, err := ReadPassphrase(keystore.PassphraseEnv, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return keystore.decrypt(passphrase)
+}
+
+func parseKeystore(data []byte) (*Keystore, error) {
+	var keystore Keystore
+	if err := json.Unmarshal(data, &keystore); err != nil {
+		return nil, fmt.Errorf("invalid keystore: %w", err)
+	}
+
+	if keystore.Backend != KeystoreBackend {
+		return nil, fmt.Errorf("file is not an encrypted keystore")
+	}
+	if keystore.Version != keystoreVersion {
+		return nil, fmt.Errorf("unsupported keystore version %d", keystore.Version)
+	}
+	if keystore.Crypto.Cipher != keystoreCipher || keystore.Crypto.KDF != keystoreKDF {
+		return nil, fmt.Errorf("unsupported keystore cipher %s or key derivation %s", keystore.Crypto.Cipher, keystore.Crypto.KDF)
+	}
+
+	return &keystore, nil
+}
+
+func (k *Keystore) decrypt(passphrase string) (crypto.PrivateKey, error) {
+	gcm, err := keystoreAEAD(passphrase, k.Crypto.KDFParams)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := hex.DecodeString(k.Crypto.CipherParams.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore nonce: %w", err)
+	}
+	cipherText, err := hex.DecodeString(k.Crypto.CipherText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore cipher text: %w", err)
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid keystore nonce length %d", len(nonce))
+	}
+
+	plainText, err := gcm.Open(nil, nonce, cipherText, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt the keystore, the passphrase is incorrect")
+	}
+
+	sigAlgo := crypto.StringToSignatureAlgorithm(k.SigAlgo)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("invalid keystore signature algorithm %s", k.SigAlgo)
+	}
+
+	return crypto.DecodePrivateKey(sigAlgo, plainText)
+}
+
+// validate checks the key derivation parameters are within the supported limits.
+func (p keystoreKDFParams) validate() error {
+	if p.DKLen != keystoreKeyLen {
+		return fmt.Errorf("unsupported keystore key length %d, must be %d", p.DKLen, keystoreKeyLen)
+	}
+	if p.N <= 1 || p.N&(p.N-1) != 0 {
+		return fmt.Errorf("invalid keystore scrypt parameter N %d, must be a power of two", p.N)
+	}
+	if p.R < 1 || p.P < 1 || p.P > scryptMaxP {
+		return fmt.Errorf("invalid keystore scrypt parameters r %d and p %d", p.R, p.P)
+	}
+	if p.N > scryptMaxMemory/128/p.R {
+		return fmt.Errorf(
+			"keystore scrypt parameters N %d and r %d exceed the memory limit of %d MiB",
+			p.N,
+			p.R,
+			scryptMaxMemory>>20,
+		)
+	}
+
+	return nil
+}
+
+// keystoreAEAD derives the encryption key from the passphrase and creates the AES-GCM cipher.
+func keystoreAEAD(passphrase string, params keystoreKDFParams) (cipher.AEAD, error) {
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore salt: %w", err)
+	}
+
+	key, err := scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, params.DKLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive the keystore key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// ReadPassphrase reads the passphrase from the environment variable or prompts for it if the variable is not set.
+func ReadPassphrase(env string, confirm bool) (string, error) {
+	if env == "" {
+		env = PassphraseEnv
+	}
+	if passphrase, ok := os.LookupEnv(env); ok {
+		return passphrase, nil
+	}
+
+	return prompt.PassphrasePrompt("Keystore passphrase", confirm)
+}
+
+// keystoreBackend decrypts the private key from the keystore when the key is first used to sign.
+type keystoreBackend struct {
+	keystore   *Keystore
+	privateKey crypto.PrivateKey
+}
+
+func newKeystoreBackend(descriptor []byte) (Backend, error) {
+	keystore, err := parseKeystore(descriptor)
+	if err != nil {
+		return nil, err
+	}
+
+	return &keystoreBackend{keystore: keystore}, nil
+}
+
+func (k *keystoreBackend) Signer(
+	_ context.Context,
+	_ crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+) (crypto.Signer, error) {
+	if k.privateKey == nil {
+		passphrase, err := ReadPassphrase(k.keystore.PassphraseEnv, false)
+		if err != nil {
+			return nil, err
+		}
+
+		privateKey, err := k.keystore.decrypt(passphrase)
+		if err != nil {
+			return nil, err
+		}
+		k.privateKey = privateKey
+	}
+
+	signer, err := crypto.NewInMemorySigner(k.privateKey, hashAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &signer, nil
+}
@@ -0,0 +1,96 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keybackend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+func init() {
+	register("pkcs11", newPKCS11Backend)
+}
+
+// pkcs11Backend signs with a key stored in a PKCS#11 token, like a hardware security module or SoftHSM.
+//
+// The signing is done by the OpenSC pkcs11-tool, so no native PKCS#11 bindings are required by the CLI.
+// The token pin is passed to the tool with the env: pin syntax which reads it from the environment.
+type pkcs11Backend struct {
+	Tool      string `json:"tool"`
+	Module    string `json:"module"`
+	Slot      string `json:"slot"`
+	ID        string `json:"id"`
+	Label     string `json:"label"`
+	PinEnv    string `json:"pinEnv"`
+	PublicKey string `json:"publicKey"`
+}
+
+func newPKCS11Backend(descriptor []byte) (Backend, error) {
+	backend := &pkcs11Backend{
+		Tool:   "pkcs11-tool",
+		PinEnv: "FLOW_PKCS11_PIN",
+	}
+	if err := json.Unmarshal(descriptor, backend); err != nil {
+		return nil, fmt.Errorf("invalid pkcs11 key backend descriptor: %w", err)
+	}
+
+	if backend.Module == "" {
+		return nil, fmt.Errorf("pkcs11 module path must be provided")
+	}
+	if backend.ID == "" && backend.Label == "" {
+		return nil, fmt.Errorf("pkcs11 key id or label must be provided")
+	}
+
+	return backend, nil
+}
+
+func (p *pkcs11Backend) Signer(
+	ctx context.Context,
+	sigAlgo crypto.SignatureAlgorithm,
+	hashAlgo crypto.HashAlgorithm,
+) (crypto.Signer, error) {
+	publicKey, err := decodePublicKey(sigAlgo, p.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := secretFromEnv(p.PinEnv); err != nil {
+		return nil, fmt.Errorf("pkcs11 token pin is required: %w", err)
+	}
+
+	// the pin is read by the tool from the inherited environment, so it is not visible in the process arguments
+	args := []string{"--module", p.Module, "--pin", "env:" + p.PinEnv, "--sign", "--mechanism", "ECDSA"}
+	if p.Slot != "" {
+		args = append(args, "--slot", p.Slot)
+	}
+	if p.ID != "" {
+		args = append(args, "--id", p.ID)
+	}
+	if p.Label != "" {
+		args = append(args, "--label", p.Label)
+	}
+
+	return newDigestSigner(publicKey, hashAlgo, func(digest []byte) ([]byte, error) {
+		// the raw ECDSA mechanism output is the r and s values concatenation as required by Flow
+		return runCommand(ctx, digest, p.Tool, args...)
+	})
+}
@@ -0,0 +1,74 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keybackend
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+var _ crypto.Signer = &digestSigner{}
+
+// digestSigner hashes the message and signs the digest using the sign function of the backend.
+type digestSigner struct {
+	publicKey crypto.PublicKey
+	hasher    crypto.Hasher
+	sign      func(digest []byte) ([]byte, error)
+}
+
+func newDigestSigner(
+	publicKey crypto.PublicKey,
+	hashAlgo crypto.HashAlgorithm,
+	sign func(digest []byte) ([]byte, error),
+) (*digestSigner, error) {
+	if !crypto.CompatibleAlgorithms(publicKey.Algorithm(), hashAlgo) {
+		return nil, fmt.Errorf("signature algorithm %s and hashing algorithm %s are incompatible", publicKey.Algorithm(), hashAlgo)
+	}
+
+	hasher, err := crypto.NewHasher(hashAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &digestSigner{publicKey: publicKey, hasher: hasher, sign: sign}, nil
+}
+
+func (s *digestSigner) Sign(message []byte) ([]byte, error) {
+	return s.sign(s.hasher.ComputeHash(message))
+}
+
+func (s *digestSigner) PublicKey() crypto.PublicKey {
+	return s.publicKey
+}
+
+// decodePublicKey decodes the hex encoded public key of the descriptor.
+func decodePublicKey(sigAlgo crypto.SignatureAlgorithm, value string) (crypto.PublicKey, error) {
+	if value == "" {
+		return nil, fmt.Errorf("public key must be provided in the key backend descriptor")
+	}
+
+	publicKey, err := crypto.DecodePublicKeyHex(sigAlgo, strings.TrimPrefix(value, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key in the key backend descriptor: %w", err)
+	}
+
+	return publicKey, nil
+}
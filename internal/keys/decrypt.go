@@ -0,0 +1,114 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/accounts"
+	"github.com/onflow/flowkit/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/keybackend"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsDecrypt struct {
+	Output string `default:"" flag:"output" info:"Plaintext private key file location, defaults to <account>.pkey"`
+}
+
+var decryptFlags = flagsDecrypt{}
+
+var decryptCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "decrypt <account name>",
+		Short:   "Decrypt the keystore of an account into a plaintext private key file",
+		Example: "flow keys decrypt alice",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &decryptFlags,
+	RunS:  decrypt,
+}
+
+// decrypt migrates the keystore key of the account back to a plaintext private key file
+// and updates the account in the configuration to reference the private key file.
+func decrypt(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	account, err := state.Accounts().ByName(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := account.Key.(*keybackend.Key); !ok {
+		return nil, fmt.Errorf("key of account %s is not stored in a keystore", account.Name)
+	}
+
+	keystore := account.Key.ToConfig().Location
+	data, err := state.ReaderWriter().ReadFile(keystore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore %s: %w", keystore, err)
+	}
+
+	privateKey, err := keybackend.Unlock(data)
+	if err != nil {
+		return nil, err
+	}
+
+	location := decryptFlags.Output
+	if location == "" {
+		location = accounts.PrivateKeyFile(account.Name, "")
+	}
+
+	err = state.ReaderWriter().WriteFile(location, []byte(privateKey.String()), os.FileMode(0600))
+	if err != nil {
+		return nil, fmt.Errorf("failed saving private key: %w", err)
+	}
+
+	err = util.AddToGitIgnore(location, state.ReaderWriter())
+	if err != nil {
+		return nil, err
+	}
+
+	account.Key = accounts.NewFileKey(
+		location,
+		account.Key.Index(),
+		account.Key.SigAlgo(),
+		account.Key.HashAlgo(),
+		state.ReaderWriter(),
+	)
+	state.Accounts().AddOrUpdate(account)
+
+	err = state.SaveDefault()
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(fmt.Sprintf("Account %s updated in the configuration to use the private key file %s.", account.Name, location))
+
+	return &keystoreResult{account: account.Name, location: location}, nil
+}
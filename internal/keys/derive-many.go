@@ -0,0 +1,275 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/accounts"
+	"github.com/onflow/flowkit/config"
+	"github.com/onflow/flowkit/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsDeriveMany struct {
+	Mnemonic       string `default:"" flag:"mnemonic" info:"Mnemonic seed the keys are derived from"`
+	Count          int    `default:"10" flag:"count" info:"Number of keys to derive"`
+	Start          int    `default:"0" flag:"start" info:"Index of the first derived key"`
+	BasePath       string `default:"m/44'/539'/0'/0" flag:"base-path" info:"Derivation path the key index is appended to"`
+	KeySigAlgo     string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm"`
+	HashAlgo       string `default:"SHA3_256" flag:"hash-algo" info:"Hashing algorithm of the created accounts keys"`
+	CreateAccounts bool   `default:"false" flag:"create-accounts" info:"Create an emulator account for every key and save it to the configuration as account-<index>"`
+}
+
+var deriveManyFlags = flagsDeriveMany{}
+
+var deriveManyCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "derive-many",
+		Short: "Derive multiple keys from a mnemonic",
+		Example: `flow keys derive-many --mnemonic "$MNEMONIC" --count 20 --start 0
+
+flow keys derive-many --mnemonic "$MNEMONIC" --count 5 --create-accounts`,
+		Args: cobra.NoArgs,
+	},
+	Flags: &deriveManyFlags,
+	Run:   deriveMany,
+}
+
+// derivedKey is the key derived at the path and the address of the account created for the key.
+type derivedKey struct {
+	path       string
+	privateKey crypto.PrivateKey
+	account    string
+	address    flowsdk.Address
+}
+
+func deriveMany(
+	_ []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	rw flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	if deriveManyFlags.Mnemonic == "" {
+		return nil, fmt.Errorf("mnemonic must be provided with the mnemonic flag")
+	}
+	if deriveManyFlags.Count <= 0 {
+		return nil, fmt.Errorf("count must be a positive number")
+	}
+	if deriveManyFlags.Start < 0 {
+		return nil, fmt.Errorf("start must not be a negative number")
+	}
+
+	sigAlgo := crypto.StringToSignatureAlgorithm(deriveManyFlags.KeySigAlgo)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("invalid signature algorithm: %s", deriveManyFlags.KeySigAlgo)
+	}
+
+	keys := make([]derivedKey, 0, deriveManyFlags.Count)
+	for i := deriveManyFlags.Start; i < deriveManyFlags.Start+deriveManyFlags.Count; i++ {
+		path := fmt.Sprintf("%s/%d", strings.TrimSuffix(deriveManyFlags.BasePath, "/"), i)
+
+		privateKey, err := flow.DerivePrivateKeyFromMnemonic(context.Background(), deriveManyFlags.Mnemonic, sigAlgo, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive key at path %s: %w", path, err)
+		}
+
+		keys = append(keys, derivedKey{path: path, privateKey: privateKey})
+	}
+
+	if deriveManyFlags.CreateAccounts {
+		err := createDerivedAccounts(keys, globalFlags, logger, rw, flow)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &deriveManyResult{keys: keys}, nil
+}
+
+// createDerivedAccounts creates an emulator account for every derived key and saves the accounts
+// to the configuration named after the derivation index, so the same mnemonic always produces
+// the same configuration on a fresh emulator.
+func createDerivedAccounts(
+	keys []derivedKey,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	rw flowkit.ReaderWriter,
+	flow flowkit.Services,
+) error {
+	if flow.Network().Name != config.EmulatorNetwork.Name {
+		return fmt.Errorf("accounts can only be created on the emulator network")
+	}
+
+	hashAlgo := crypto.StringToHashAlgorithm(deriveManyFlags.HashAlgo)
+	if !crypto.CompatibleAlgorithms(keys[0].privateKey.Algorithm(), hashAlgo) {
+		return fmt.Errorf("invalid hashing algorithm %s for signature algorithm %s", deriveManyFlags.HashAlgo, keys[0].privateKey.Algorithm())
+	}
+
+	state, err := flowkit.Load(globalFlags.ConfigPaths, rw)
+	if err != nil {
+		return err
+	}
+
+	for i := range keys {
+		name := derivedAccountName(i)
+		if _, err := state.Accounts().ByName(name); err == nil {
+			return fmt.Errorf("account with name %s already exists in the configuration", name)
+		}
+	}
+
+	serviceAccount, err := state.EmulatorServiceAccount()
+	if err != nil {
+		return err
+	}
+
+	logger.StartProgress(fmt.Sprintf("Creating %d emulator accounts...", len(keys)))
+	defer logger.StopProgress()
+
+	for i, key := range keys {
+		account, _, err := flow.CreateAccount(context.Background(), serviceAccount, []accounts.PublicKey{{
+			Public:   key.privateKey.PublicKey(),
+			Weight:   flowsdk.AccountKeyWeightThreshold,
+			SigAlgo:  key.privateKey.Algorithm(),
+			HashAlgo: hashAlgo,
+		}})
+		if err != nil {
+			return saveCreatedAccounts(state, i, fmt.Errorf("failed to create account for key %s: %w", key.path, err))
+		}
+
+		name := derivedAccountName(i)
+		privateFile := accounts.PrivateKeyFile(name, "")
+		err = util.AddToGitIgnore(privateFile, state.ReaderWriter())
+		if err != nil {
+			return saveCreatedAccounts(state, i, err)
+		}
+
+		err = state.ReaderWriter().WriteFile(privateFile, []byte(key.privateKey.String()), os.FileMode(0644))
+		if err != nil {
+			return saveCreatedAccounts(state, i, fmt.Errorf("failed saving private key: %w", err))
+		}
+
+		state.Accounts().AddOrUpdate(&accounts.Account{
+			Name:    name,
+			Address: account.Address,
+			Key:     accounts.NewFileKey(privateFile, 0, key.privateKey.Algorithm(), hashAlgo, state.ReaderWriter()),
+		})
+
+		keys[i].account = name
+		keys[i].address = account.Address
+	}
+
+	err = state.SaveDefault()
+	if err != nil {
+		return err
+	}
+
+	logger.StopProgress()
+	logger.Info(fmt.Sprintf("%d accounts added to the configuration.", len(keys)))
+
+	return nil
+}
+
+// derivedAccountName returns the configuration name of the account created for the i-th derived key.
+func derivedAccountName(i int) string {
+	return fmt.Sprintf("account-%d", deriveManyFlags.Start+i)
+}
+
+// saveCreatedAccounts saves the accounts created before the creation failed, so they are not lost
+// since they already exist on the emulator, and returns the creation error.
+func saveCreatedAccounts(state *flowkit.State, created int, createErr error) error {
+	if created == 0 {
+		return createErr
+	}
+
+	err := state.SaveDefault()
+	if err != nil {
+		return fmt.Errorf("%w, saving the %d accounts created before the failure also failed: %v", createErr, created, err)
+	}
+
+	return fmt.Errorf("%w, the %d accounts created before the failure were added to the configuration", createErr, created)
+}
+
+type deriveManyResult struct {
+	keys []derivedKey
+}
+
+func (r *deriveManyResult) JSON() any {
+	keys := make([]any, 0, len(r.keys))
+	for _, key := range r.keys {
+		k := map[string]any{
+			"derivationPath": key.path,
+			"private":        hex.EncodeToString(key.privateKey.Encode()),
+			"public":         hex.EncodeToString(key.privateKey.PublicKey().Encode()),
+		}
+		if key.account != "" {
+			k["account"] = key.account
+			k["address"] = fmt.Sprintf("0x%s", key.address)
+		}
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+func (r *deriveManyResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "%s Store private keys safely and don't share with anyone! \n\n", output.StopEmoji())
+
+	_, _ = fmt.Fprintf(writer, "Derivation Path\t Private Key\t Public Key")
+	if r.keys[0].account != "" {
+		_, _ = fmt.Fprintf(writer, "\t Account\t Address")
+	}
+	_, _ = fmt.Fprintf(writer, "\n")
+
+	for _, key := range r.keys {
+		_, _ = fmt.Fprintf(writer, "%s\t %x\t %x", key.path, key.privateKey.Encode(), key.privateKey.PublicKey().Encode())
+		if key.account != "" {
+			_, _ = fmt.Fprintf(writer, "\t %s\t 0x%s", key.account, key.address)
+		}
+		_, _ = fmt.Fprintf(writer, "\n")
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *deriveManyResult) Oneliner() string {
+	keys := make([]string, 0, len(r.keys))
+	for _, key := range r.keys {
+		keys = append(keys, fmt.Sprintf("%s: %x", key.path, key.privateKey.PublicKey().Encode()))
+	}
+
+	return strings.Join(keys, ", ")
+}
@@ -0,0 +1,168 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/accounts"
+	"github.com/onflow/flowkit/config"
+	"github.com/onflow/flowkit/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/keybackend"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsEncrypt struct {
+	Output        string `default:"" flag:"output" info:"Keystore file location, defaults to <account>.keystore.json"`
+	PassphraseEnv string `default:"FLOW_KEYSTORE_PASSPHRASE" flag:"passphrase-env" info:"Environment variable the passphrase is read from, the passphrase is prompted if the variable is not set"`
+}
+
+var encryptFlags = flagsEncrypt{}
+
+var encryptCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "encrypt <account name>",
+		Short: "Encrypt the private key of an account into a keystore",
+		Example: `flow keys encrypt alice
+
+FLOW_ALICE_PASSPHRASE=secret flow keys encrypt alice --passphrase-env FLOW_ALICE_PASSPHRASE`,
+		Args: cobra.ExactArgs(1),
+	},
+	Flags: &encryptFlags,
+	RunS:  encrypt,
+}
+
+// keystoreFile returns the default keystore file location for the account.
+func keystoreFile(name string) string {
+	return fmt.Sprintf("%s.keystore.json", name)
+}
+
+// encrypt migrates the hex or file key of the account to an encrypted keystore file
+// and updates the account in the configuration to reference the keystore.
+func encrypt(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	account, err := state.Accounts().ByName(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := account.Key.(*keybackend.Key); ok {
+		return nil, fmt.Errorf("key of account %s is already managed by a key backend", account.Name)
+	}
+
+	privateKey, err := account.Key.PrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the private key of account %s: %w", account.Name, err)
+	}
+
+	passphrase, err := keybackend.ReadPassphrase(encryptFlags.PassphraseEnv, true)
+	if err != nil {
+		return nil, err
+	}
+	if passphrase == "" {
+		return nil, fmt.Errorf("passphrase must not be empty")
+	}
+
+	keystore, err := keybackend.Encrypt(*privateKey, passphrase, encryptFlags.PassphraseEnv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt the private key: %w", err)
+	}
+
+	location := encryptFlags.Output
+	if location == "" {
+		location = keystoreFile(account.Name)
+	}
+
+	err = state.ReaderWriter().WriteFile(location, keystore, os.FileMode(0600))
+	if err != nil {
+		return nil, fmt.Errorf("failed saving keystore: %w", err)
+	}
+
+	plaintext := ""
+	if account.Key.Type() == config.KeyTypeFile {
+		plaintext = account.Key.ToConfig().Location
+	}
+
+	account.Key = accounts.NewFileKey(
+		location,
+		account.Key.Index(),
+		account.Key.SigAlgo(),
+		account.Key.HashAlgo(),
+		state.ReaderWriter(),
+	)
+	state.Accounts().AddOrUpdate(account)
+
+	err = state.SaveDefault()
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info(fmt.Sprintf("Account %s updated in the configuration to use the keystore %s.", account.Name, location))
+	if plaintext != "" {
+		logger.Info(fmt.Sprintf("The plaintext private key is still stored in %s, delete the file once you verified the keystore.", plaintext))
+	}
+
+	return &keystoreResult{account: account.Name, location: location, encrypted: true}, nil
+}
+
+type keystoreResult struct {
+	account   string
+	location  string
+	encrypted bool
+}
+
+func (r *keystoreResult) JSON() any {
+	return map[string]any{
+		"account":   r.account,
+		"location":  r.location,
+		"encrypted": r.encrypted,
+	}
+}
+
+func (r *keystoreResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	action := "decrypted"
+	if r.encrypted {
+		action = "encrypted"
+	}
+
+	_, _ = fmt.Fprintf(writer, "%s Private key of account %s %s\n\n", output.SuccessEmoji(), r.account, action)
+	_, _ = fmt.Fprintf(writer, "Location\t %s\n", r.location)
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *keystoreResult) Oneliner() string {
+	return fmt.Sprintf("Account: %s, Location: %s, Encrypted: %t", r.account, r.location, r.encrypted)
+}
@@ -0,0 +1,216 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/config"
+	"github.com/onflow/flowkit/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsExport struct {
+	Format    string `default:"pem" flag:"format" info:"Export format. Valid values: pem, jwk, der"`
+	SigAlgo   string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm of the hex encoded key"`
+	Public    bool   `default:"false" flag:"public" info:"Export only the public key"`
+	PublicKey string `default:"" flag:"public-key" info:"Hex encoded public key to export instead of a private key"`
+	Output    string `default:"" flag:"output" info:"File the exported key is saved to"`
+}
+
+var exportFlags = flagsExport{}
+
+var exportCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "export [<account name|hex private key>]",
+		Short: "Export a key in the PEM, JWK or DER format",
+		Example: `flow keys export alice --format pem
+
+flow keys export alice --format jwk --public
+
+flow keys export --public-key 84d716c1...d342db24 --format der --output key.der`,
+		Args: cobra.MaximumNArgs(1),
+	},
+	Flags: &exportFlags,
+	Run:   export,
+}
+
+func export(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	rw flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	sigAlgo := crypto.StringToSignatureAlgorithm(exportFlags.SigAlgo)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("invalid signature algorithm: %s", exportFlags.SigAlgo)
+	}
+
+	var privateKey crypto.PrivateKey
+	var publicKey crypto.PublicKey
+	switch {
+	case exportFlags.PublicKey != "" && len(args) > 0:
+		return nil, fmt.Errorf("can not pass both command argument and public key flag")
+	case exportFlags.PublicKey != "":
+		var err error
+		publicKey, err = crypto.DecodePublicKeyHex(sigAlgo, strings.TrimPrefix(exportFlags.PublicKey, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode public key: %w", err)
+		}
+	case len(args) > 0:
+		var err error
+		privateKey, err = exportPrivateKey(args[0], sigAlgo, globalFlags, rw)
+		if err != nil {
+			return nil, err
+		}
+		publicKey = privateKey.PublicKey()
+	default:
+		return nil, fmt.Errorf("provide an account name or private key argument or use the public key flag")
+	}
+
+	if exportFlags.Public {
+		privateKey = nil
+	}
+
+	data, err := encodeKey(exportFlags.Format, privateKey, publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &exportResult{
+		format:  strings.ToLower(exportFlags.Format),
+		private: privateKey != nil,
+		data:    data,
+	}
+
+	if exportFlags.Output != "" {
+		mode := os.FileMode(0644)
+		if result.private {
+			mode = os.FileMode(0600)
+		}
+
+		err = rw.WriteFile(exportFlags.Output, data, mode)
+		if err != nil {
+			return nil, fmt.Errorf("failed saving exported key: %w", err)
+		}
+		result.location = exportFlags.Output
+		logger.Info(fmt.Sprintf("Key exported to %s.", exportFlags.Output))
+	}
+
+	return result, nil
+}
+
+// exportPrivateKey returns the private key of the account in the configuration with the name
+// or decodes the value as a hex encoded private key if no such account exists.
+func exportPrivateKey(
+	value string,
+	sigAlgo crypto.SignatureAlgorithm,
+	globalFlags command.GlobalFlags,
+	rw flowkit.ReaderWriter,
+) (crypto.PrivateKey, error) {
+	state, err := flowkit.Load(globalFlags.ConfigPaths, rw)
+	if err != nil && !errors.Is(err, config.ErrDoesNotExist) {
+		return nil, err
+	}
+
+	if state != nil {
+		if account, err := state.Accounts().ByName(value); err == nil {
+			privateKey, err := account.Key.PrivateKey()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read the private key of account %s: %w", account.Name, err)
+			}
+			return *privateKey, nil
+		}
+	}
+
+	privateKey, err := crypto.DecodePrivateKeyHex(sigAlgo, strings.TrimPrefix(value, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("%s is not an account in the configuration and failed to decode it as private key: %w", value, err)
+	}
+
+	return privateKey, nil
+}
+
+type exportResult struct {
+	format   string
+	private  bool
+	data     []byte
+	location string
+}
+
+// encoded returns the exported key as text, the binary DER encoding is hex encoded.
+func (r *exportResult) encoded() string {
+	if r.format == formatDER {
+		return hex.EncodeToString(r.data)
+	}
+
+	return strings.TrimSpace(string(r.data))
+}
+
+func (r *exportResult) keyType() string {
+	if r.private {
+		return "private"
+	}
+
+	return "public"
+}
+
+func (r *exportResult) JSON() any {
+	result := map[string]any{
+		"format": r.format,
+		"type":   r.keyType(),
+		"key":    r.encoded(),
+	}
+	if r.location != "" {
+		result["location"] = r.location
+	}
+
+	return result
+}
+
+func (r *exportResult) String() string {
+	if r.location != "" {
+		return fmt.Sprintf("%s Exported %s key in %s format to %s", output.SuccessEmoji(), r.keyType(), r.format, r.location)
+	}
+
+	result := r.encoded()
+	if r.private {
+		result = fmt.Sprintf("%s Store private key safely and don't share with anyone!\n%s", output.StopEmoji(), result)
+	}
+
+	return result
+}
+
+func (r *exportResult) Oneliner() string {
+	if r.location != "" {
+		return fmt.Sprintf("Format: %s, Type: %s, Location: %s", r.format, r.keyType(), r.location)
+	}
+
+	return r.encoded()
+}
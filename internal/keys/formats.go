@@ -0,0 +1,349 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+const (
+	formatPEM = "pem"
+	formatJWK = "jwk"
+	formatDER = "der"
+
+	// scalarLength is the length of the private key and the point coordinates of the supported curves.
+	scalarLength = 32
+)
+
+var (
+	oidECPublicKey = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidP256        = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+	oidSecp256k1   = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+// jwkCurves maps the signature algorithms to the JWK curve names.
+var jwkCurves = map[crypto.SignatureAlgorithm]string{
+	crypto.ECDSA_P256:      "P-256",
+	crypto.ECDSA_secp256k1: "secp256k1",
+}
+
+// ecPrivateKey is the SEC 1 private key structure (RFC 5915).
+type ecPrivateKey struct {
+	Version       int
+	PrivateKey    []byte
+	NamedCurveOID asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey     asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+// pkcs8PrivateKey is the PKCS #8 private key structure (RFC 5208) used by most KMS exports.
+type pkcs8PrivateKey struct {
+	Version    int
+	Algorithm  pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+// subjectPublicKeyInfo is the X.509 public key structure (RFC 5480).
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	D   string `json:"d,omitempty"`
+}
+
+func curveOID(sigAlgo crypto.SignatureAlgorithm) (asn1.ObjectIdentifier, error) {
+	switch sigAlgo {
+	case crypto.ECDSA_P256:
+		return oidP256, nil
+	case crypto.ECDSA_secp256k1:
+		return oidSecp256k1, nil
+	default:
+		return nil, fmt.Errorf("unsupported signature algorithm: %s", sigAlgo)
+	}
+}
+
+func sigAlgoFromOID(oid asn1.ObjectIdentifier, fallback crypto.SignatureAlgorithm) (crypto.SignatureAlgorithm, error) {
+	switch {
+	case len(oid) == 0:
+		return fallback, nil
+	case oid.Equal(oidP256):
+		return crypto.ECDSA_P256, nil
+	case oid.Equal(oidSecp256k1):
+		return crypto.ECDSA_secp256k1, nil
+	default:
+		return crypto.UnknownSignatureAlgorithm, fmt.Errorf("unsupported elliptic curve %s", oid)
+	}
+}
+
+// uncompressedPoint returns the public key encoded as an uncompressed elliptic curve point.
+func uncompressedPoint(publicKey crypto.PublicKey) []byte {
+	return append([]byte{0x04}, publicKey.Encode()...)
+}
+
+func decodePoint(point []byte, sigAlgo crypto.SignatureAlgorithm) (crypto.PublicKey, error) {
+	if len(point) == 0 || point[0] != 0x04 {
+		return nil, fmt.Errorf("only uncompressed public keys are supported")
+	}
+
+	return crypto.DecodePublicKey(sigAlgo, point[1:])
+}
+
+// leftPad pads the big-endian encoded scalar to the private key length.
+func leftPad(value []byte, length int) []byte {
+	if len(value) >= length {
+		return value
+	}
+
+	return append(make([]byte, length-len(value)), value...)
+}
+
+func marshalPrivateKeyDER(privateKey crypto.PrivateKey) ([]byte, error) {
+	oid, err := curveOID(privateKey.Algorithm())
+	if err != nil {
+		return nil, err
+	}
+
+	point := uncompressedPoint(privateKey.PublicKey())
+	return asn1.Marshal(ecPrivateKey{
+		Version:       1,
+		PrivateKey:    privateKey.Encode(),
+		NamedCurveOID: oid,
+		PublicKey:     asn1.BitString{Bytes: point, BitLength: len(point) * 8},
+	})
+}
+
+func marshalPublicKeyDER(publicKey crypto.PublicKey) ([]byte, error) {
+	oid, err := curveOID(publicKey.Algorithm())
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := asn1.Marshal(oid)
+	if err != nil {
+		return nil, err
+	}
+
+	point := uncompressedPoint(publicKey)
+	return asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  oidECPublicKey,
+			Parameters: asn1.RawValue{FullBytes: params},
+		},
+		PublicKey: asn1.BitString{Bytes: point, BitLength: len(point) * 8},
+	})
+}
+
+// parsePrivateKeyDER parses a SEC 1 or PKCS #8 encoded private key, the signature algorithm
+// is used if the curve is not included in the encoding.
+func parsePrivateKeyDER(der []byte, sigAlgo crypto.SignatureAlgorithm) (crypto.PrivateKey, error) {
+	var pkcs8 pkcs8PrivateKey
+	if _, err := asn1.Unmarshal(der, &pkcs8); err == nil && pkcs8.Algorithm.Algorithm.Equal(oidECPublicKey) {
+		var oid asn1.ObjectIdentifier
+		if _, err := asn1.Unmarshal(pkcs8.Algorithm.Parameters.FullBytes, &oid); err == nil {
+			sigAlgo, err = sigAlgoFromOID(oid, sigAlgo)
+			if err != nil {
+				return nil, err
+			}
+		}
+		der = pkcs8.PrivateKey
+	}
+
+	var key ecPrivateKey
+	if _, err := asn1.Unmarshal(der, &key); err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	if key.Version != 1 {
+		return nil, fmt.Errorf("unsupported private key version %d", key.Version)
+	}
+
+	sigAlgo, err := sigAlgoFromOID(key.NamedCurveOID, sigAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.DecodePrivateKey(sigAlgo, leftPad(key.PrivateKey, scalarLength))
+}
+
+func parsePublicKeyDER(der []byte) (crypto.PublicKey, error) {
+	var info subjectPublicKeyInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+	if !info.Algorithm.Algorithm.Equal(oidECPublicKey) {
+		return nil, fmt.Errorf("public key is not an elliptic curve key")
+	}
+
+	var oid asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &oid); err != nil {
+		return nil, fmt.Errorf("failed to parse public key curve: %w", err)
+	}
+
+	sigAlgo, err := sigAlgoFromOID(oid, crypto.UnknownSignatureAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodePoint(info.PublicKey.RightAlign(), sigAlgo)
+}
+
+// encodeKey encodes the private key, or only the public key if no private key is provided, in the format.
+func encodeKey(format string, privateKey crypto.PrivateKey, publicKey crypto.PublicKey) ([]byte, error) {
+	var der []byte
+	var err error
+	if privateKey != nil {
+		der, err = marshalPrivateKeyDER(privateKey)
+	} else {
+		der, err = marshalPublicKeyDER(publicKey)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(format) {
+	case formatDER:
+		return der, nil
+	case formatPEM:
+		blockType := "PUBLIC KEY"
+		if privateKey != nil {
+			blockType = "EC PRIVATE KEY"
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), nil
+	case formatJWK:
+		return encodeJWK(privateKey, publicKey)
+	default:
+		return nil, fmt.Errorf("format not supported. Valid formats: pem, jwk and der")
+	}
+}
+
+func encodeJWK(privateKey crypto.PrivateKey, publicKey crypto.PublicKey) ([]byte, error) {
+	curve, ok := jwkCurves[publicKey.Algorithm()]
+	if !ok {
+		return nil, fmt.Errorf("unsupported signature algorithm: %s", publicKey.Algorithm())
+	}
+
+	point := publicKey.Encode()
+	key := jwk{
+		Kty: "EC",
+		Crv: curve,
+		X:   base64.RawURLEncoding.EncodeToString(point[:len(point)/2]),
+		Y:   base64.RawURLEncoding.EncodeToString(point[len(point)/2:]),
+	}
+	if privateKey != nil {
+		key.D = base64.RawURLEncoding.EncodeToString(privateKey.Encode())
+	}
+
+	return json.MarshalIndent(key, "", "  ")
+}
+
+// decodeKey decodes the key in the format and returns the private key if the encoding contains one
+// and the public key, the signature algorithm is used if the curve is not included in the encoding.
+func decodeKey(
+	format string,
+	data []byte,
+	sigAlgo crypto.SignatureAlgorithm,
+) (crypto.PrivateKey, crypto.PublicKey, error) {
+	switch strings.ToLower(format) {
+	case formatPEM:
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, nil, fmt.Errorf("failed to decode PEM block")
+		}
+		return decodeDER(block.Bytes, sigAlgo)
+	case formatDER:
+		return decodeDER(data, sigAlgo)
+	case formatJWK:
+		return decodeJWK(data)
+	default:
+		return nil, nil, fmt.Errorf("format not supported. Valid formats: pem, jwk and der")
+	}
+}
+
+func decodeDER(der []byte, sigAlgo crypto.SignatureAlgorithm) (crypto.PrivateKey, crypto.PublicKey, error) {
+	if publicKey, err := parsePublicKeyDER(der); err == nil {
+		return nil, publicKey, nil
+	}
+
+	privateKey, err := parsePrivateKeyDER(der, sigAlgo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return privateKey, privateKey.PublicKey(), nil
+}
+
+func decodeJWK(data []byte) (crypto.PrivateKey, crypto.PublicKey, error) {
+	var key jwk
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode JWK: %w", err)
+	}
+	if key.Kty != "EC" {
+		return nil, nil, fmt.Errorf("unsupported JWK key type %s", key.Kty)
+	}
+
+	sigAlgo := crypto.UnknownSignatureAlgorithm
+	for algo, curve := range jwkCurves {
+		if curve == key.Crv {
+			sigAlgo = algo
+		}
+	}
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, nil, fmt.Errorf("unsupported JWK curve %s", key.Crv)
+	}
+
+	if key.D != "" {
+		d, err := base64.RawURLEncoding.DecodeString(key.D)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to decode JWK private key: %w", err)
+		}
+		privateKey, err := crypto.DecodePrivateKey(sigAlgo, leftPad(d, scalarLength))
+		if err != nil {
+			return nil, nil, err
+		}
+		return privateKey, privateKey.PublicKey(), nil
+	}
+
+	x, err := base64.RawURLEncoding.DecodeString(key.X)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode JWK x coordinate: %w", err)
+	}
+	y, err := base64.RawURLEncoding.DecodeString(key.Y)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode JWK y coordinate: %w", err)
+	}
+
+	publicKey, err := crypto.DecodePublicKey(sigAlgo, append(leftPad(x, scalarLength), leftPad(y, scalarLength)...))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return nil, publicKey, nil
+}
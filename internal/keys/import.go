@@ -0,0 +1,128 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"fmt"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/accounts"
+	"github.com/onflow/flowkit/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsImport struct {
+	Format   string `default:"pem" flag:"format" info:"Format of the imported key. Valid values: pem, jwk, der"`
+	SigAlgo  string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm used if the curve is not included in the key encoding"`
+	HashAlgo string `default:"SHA3_256" flag:"hash-algo" info:"Hashing algorithm of the account key"`
+	Account  string `default:"" flag:"account" info:"Name of the account added to the configuration with the imported key"`
+	Address  string `default:"" flag:"address" info:"Address of the account added to the configuration"`
+	KeyIndex int    `default:"0" flag:"key-index" info:"Index of the imported key on the account"`
+}
+
+var importFlags = flagsImport{}
+
+var importCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "import <key file>",
+		Short: "Import a key in the PEM, JWK or DER format",
+		Example: `flow keys import key.pem
+
+flow keys import key.jwk --format jwk --account alice --address 0x01cf0e2f2f715450 --key-index 1`,
+		Args: cobra.ExactArgs(1),
+	},
+	Flags: &importFlags,
+	Run:   importKey,
+}
+
+func importKey(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	rw flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	sigAlgo := crypto.StringToSignatureAlgorithm(importFlags.SigAlgo)
+	if sigAlgo == crypto.UnknownSignatureAlgorithm {
+		return nil, fmt.Errorf("invalid signature algorithm: %s", importFlags.SigAlgo)
+	}
+
+	data, err := rw.ReadFile(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, publicKey, err := decodeKey(importFlags.Format, data, sigAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &keyResult{
+		privateKey: privateKey,
+		publicKey:  publicKey,
+		sigAlgo:    publicKey.Algorithm(),
+	}
+
+	if importFlags.Account == "" {
+		return result, nil
+	}
+
+	if privateKey == nil {
+		return nil, fmt.Errorf("the key file contains only a public key, a private key is required to add an account")
+	}
+
+	hashAlgo := crypto.StringToHashAlgorithm(importFlags.HashAlgo)
+	if !crypto.CompatibleAlgorithms(privateKey.Algorithm(), hashAlgo) {
+		return nil, fmt.Errorf("invalid hashing algorithm %s for signature algorithm %s", importFlags.HashAlgo, privateKey.Algorithm())
+	}
+
+	address := flowsdk.HexToAddress(importFlags.Address)
+	if address == flowsdk.EmptyAddress {
+		return nil, fmt.Errorf("a valid address must be provided with the address flag")
+	}
+
+	state, err := flowkit.Load(globalFlags.ConfigPaths, rw)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := state.Accounts().ByName(importFlags.Account); err == nil {
+		return nil, fmt.Errorf("account with name %s already exists in the configuration", importFlags.Account)
+	}
+
+	state.Accounts().AddOrUpdate(&accounts.Account{
+		Name:    importFlags.Account,
+		Address: address,
+		Key:     accounts.NewHexKeyFromPrivateKey(importFlags.KeyIndex, hashAlgo, privateKey),
+	})
+
+	err = state.SaveDefault()
+	if err != nil {
+		return nil, err
+	}
+	logger.Info(fmt.Sprintf("Account %s added to the configuration.", importFlags.Account))
+
+	result.hashAlgo = hashAlgo
+	return result, nil
+}
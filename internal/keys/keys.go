@@ -42,6 +42,12 @@ func init() {
 	generateCommand.AddToParent(Cmd)
 	decodeCommand.AddToParent(Cmd)
 	deriveCommand.AddToParent(Cmd)
+	deriveManyCommand.AddToParent(Cmd)
+	encryptCommand.AddToParent(Cmd)
+	decryptCommand.AddToParent(Cmd)
+	lookupCommand.AddToParent(Cmd)
+	exportCommand.AddToParent(Cmd)
+	importCommand.AddToParent(Cmd)
 }
 
 type keyResult struct {
@@ -56,7 +62,7 @@ type keyResult struct {
 
 func (k *keyResult) JSON() any {
 	result := make(map[string]any)
-	result["public"] = hex.EncodeToString(k.privateKey.PublicKey().Encode())
+	result["public"] = hex.EncodeToString(k.publicKey.Encode())
 
 	if k.privateKey != nil {
 		result["private"] = hex.EncodeToString(k.privateKey.Encode())
@@ -19,12 +19,30 @@
 package keys
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/accounts"
+	"github.com/onflow/flowkit/config"
+	"github.com/onflow/flowkit/tests"
 
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/keybackend"
 	"github.com/onflow/flow-cli/internal/util"
 )
 
@@ -148,3 +166,350 @@ func Test_Generate(t *testing.T) {
 		assert.EqualError(t, err, "invalid signature algorithm: invalid")
 	})
 }
+
+func Test_Keystore(t *testing.T) {
+	_, state, rw := util.TestMocks(t)
+	t.Setenv(keybackend.PassphraseEnv, "secret")
+
+	privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, []byte(strings.Repeat("k", crypto.MinSeedLength)))
+	require.NoError(t, err)
+	state.Accounts().AddOrUpdate(&accounts.Account{
+		Name:    "alice",
+		Address: flow.HexToAddress("0x01"),
+		Key:     accounts.NewHexKeyFromPrivateKey(2, crypto.SHA3_256, privateKey),
+	})
+
+	t.Run("Success encrypt", func(t *testing.T) {
+		encryptFlags.PassphraseEnv = keybackend.PassphraseEnv
+		result, err := encrypt([]string{"alice"}, command.GlobalFlags{}, util.NoLogger, nil, state)
+		require.NoError(t, err)
+		assert.Equal(t, "alice.keystore.json", result.(*keystoreResult).location)
+
+		data, err := rw.ReadFile("alice.keystore.json")
+		require.NoError(t, err)
+		decrypted, err := keybackend.Decrypt(data, "secret")
+		require.NoError(t, err)
+		assert.True(t, privateKey.Equals(decrypted))
+
+		alice, err := state.Accounts().ByName("alice")
+		require.NoError(t, err)
+		assert.Equal(t, config.KeyTypeFile, alice.Key.Type())
+		assert.Equal(t, "alice.keystore.json", alice.Key.ToConfig().Location)
+		assert.Equal(t, 2, alice.Key.Index())
+	})
+
+	t.Run("Success decrypt", func(t *testing.T) {
+		keybackend.ResolveAccountKeys(state)
+
+		result, err := decrypt([]string{"alice"}, command.GlobalFlags{}, util.NoLogger, nil, state)
+		require.NoError(t, err)
+		assert.Equal(t, "alice.pkey", result.(*keystoreResult).location)
+
+		alice, err := state.Accounts().ByName("alice")
+		require.NoError(t, err)
+		decrypted, err := alice.Key.PrivateKey()
+		require.NoError(t, err)
+		assert.True(t, privateKey.Equals(*decrypted))
+	})
+
+	t.Run("Fail decrypt plaintext key", func(t *testing.T) {
+		_, err := decrypt([]string{"alice"}, command.GlobalFlags{}, util.NoLogger, nil, state)
+		assert.EqualError(t, err, "key of account alice is not stored in a keystore")
+	})
+}
+
+func Test_Lookup(t *testing.T) {
+	privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, []byte(strings.Repeat("l", crypto.MinSeedLength)))
+	require.NoError(t, err)
+	publicKey := hex.EncodeToString(privateKey.PublicKey().Encode())
+
+	t.Run("Success configured accounts", func(t *testing.T) {
+		srv, state, _ := util.TestMocks(t)
+		lookupFlags = flagsLookup{Encoding: "hex"}
+
+		account := tests.NewAccountWithAddress("f8d6e0586b0a20c7")
+		account.Keys = []*flow.AccountKey{
+			{Index: 0, PublicKey: privateKey.PublicKey(), SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256, Weight: 1000, Revoked: true},
+			{Index: 3, PublicKey: privateKey.PublicKey(), SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256, Weight: 500},
+		}
+		srv.GetAccount.Run(func(args mock.Arguments) {
+			srv.GetAccount.Return(account, nil)
+		})
+
+		result, err := lookup([]string{"0x" + publicKey}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		matches := result.(*lookupResult).matches
+		require.Len(t, matches, 2)
+		assert.Equal(t, keyMatch{
+			network: "emulator",
+			name:    "emulator-account",
+			address: account.Address,
+			index:   0,
+			weight:  1000,
+			revoked: true,
+		}, matches[0])
+		assert.Equal(t, 3, matches[1].index)
+		assert.False(t, matches[1].revoked)
+	})
+
+	t.Run("Success indexer", func(t *testing.T) {
+		_, state, _ := util.TestMocks(t)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/key/"+publicKey, r.URL.Path)
+			_, _ = fmt.Fprintf(w, `{"publicKey": "%s", "accounts": [{"address": "0x01cf0e2f2f715450", "keyId": 2, "weight": 1000, "isRevoked": false}]}`, publicKey)
+		}))
+		defer server.Close()
+		lookupFlags = flagsLookup{Encoding: "hex", Indexer: server.URL + "/key/"}
+
+		result, err := lookup([]string{publicKey}, command.GlobalFlags{}, util.NoLogger, nil, state)
+		require.NoError(t, err)
+		assert.Equal(t, []keyMatch{{
+			network: "indexer",
+			address: flow.HexToAddress("01cf0e2f2f715450"),
+			index:   2,
+			weight:  1000,
+		}}, result.(*lookupResult).matches)
+	})
+
+	t.Run("Success decode RLP", func(t *testing.T) {
+		key, err := decodeLookupKey(
+			"f847b84084d716c14b051ad6b001624f738f5d302636e6b07cc75e4530af7776a4368a2b586dbefc0564ee28384c2696f178cbed52e62811bcc9ecb59568c996d342db2402038203e8",
+			"rlp",
+			"",
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "84d716c14b051ad6b001624f738f5d302636e6b07cc75e4530af7776a4368a2b586dbefc0564ee28384c2696f178cbed52e62811bcc9ecb59568c996d342db24", key)
+	})
+
+	t.Run("Fail invalid encoding", func(t *testing.T) {
+		_, err := decodeLookupKey(publicKey, "base64", "")
+		assert.EqualError(t, err, "encoding type not supported. Valid encoding: hex, RLP and PEM")
+	})
+}
+
+func Test_ExportImport(t *testing.T) {
+	for _, sigAlgo := range []crypto.SignatureAlgorithm{crypto.ECDSA_P256, crypto.ECDSA_secp256k1} {
+		privateKey, err := crypto.GeneratePrivateKey(sigAlgo, []byte(strings.Repeat("e", crypto.MinSeedLength)))
+		require.NoError(t, err)
+
+		for _, format := range []string{formatPEM, formatJWK, formatDER} {
+			t.Run(fmt.Sprintf("Success %s %s", sigAlgo, format), func(t *testing.T) {
+				data, err := encodeKey(format, privateKey, privateKey.PublicKey())
+				require.NoError(t, err)
+
+				decodedPrivate, decodedPublic, err := decodeKey(format, data, crypto.UnknownSignatureAlgorithm)
+				require.NoError(t, err)
+				assert.True(t, privateKey.Equals(decodedPrivate))
+				assert.True(t, privateKey.PublicKey().Equals(decodedPublic))
+
+				data, err = encodeKey(format, nil, privateKey.PublicKey())
+				require.NoError(t, err)
+
+				decodedPrivate, decodedPublic, err = decodeKey(format, data, crypto.UnknownSignatureAlgorithm)
+				require.NoError(t, err)
+				assert.Nil(t, decodedPrivate)
+				assert.True(t, privateKey.PublicKey().Equals(decodedPublic))
+			})
+		}
+	}
+
+	t.Run("Success standard library interoperability", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+
+		privateKey, _, err := decodeKey(formatDER, pkcs8, crypto.UnknownSignatureAlgorithm)
+		require.NoError(t, err)
+		assert.Equal(t, crypto.ECDSA_P256, privateKey.Algorithm())
+		assert.Equal(t, key.D.FillBytes(make([]byte, 32)), privateKey.Encode())
+
+		der, err := encodeKey(formatDER, nil, privateKey.PublicKey())
+		require.NoError(t, err)
+		publicKey, err := x509.ParsePKIXPublicKey(der)
+		require.NoError(t, err)
+		assert.True(t, key.PublicKey.Equal(publicKey))
+	})
+
+	t.Run("Success import account", func(t *testing.T) {
+		_, state, rw := util.TestMocks(t)
+		require.NoError(t, state.SaveDefault())
+
+		privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_secp256k1, []byte(strings.Repeat("i", crypto.MinSeedLength)))
+		require.NoError(t, err)
+		data, err := encodeKey(formatJWK, privateKey, privateKey.PublicKey())
+		require.NoError(t, err)
+		require.NoError(t, rw.WriteFile("key.jwk", data, 0600))
+
+		importFlags = flagsImport{
+			Format:   formatJWK,
+			SigAlgo:  "ECDSA_P256",
+			HashAlgo: "SHA3_256",
+			Account:  "alice",
+			Address:  "01cf0e2f2f715450",
+			KeyIndex: 1,
+		}
+		result, err := importKey([]string{"key.jwk"}, command.GlobalFlags{ConfigPaths: []string{"flow.json"}}, util.NoLogger, rw, nil)
+		require.NoError(t, err)
+		assert.True(t, privateKey.Equals(result.(*keyResult).privateKey))
+
+		state, err = flowkit.Load([]string{"flow.json"}, rw)
+		require.NoError(t, err)
+		alice, err := state.Accounts().ByName("alice")
+		require.NoError(t, err)
+		assert.Equal(t, flow.HexToAddress("01cf0e2f2f715450"), alice.Address)
+		assert.Equal(t, 1, alice.Key.Index())
+		assert.Equal(t, crypto.ECDSA_secp256k1, alice.Key.SigAlgo())
+	})
+
+	t.Run("Fail import existing account", func(t *testing.T) {
+		_, state, rw := util.TestMocks(t)
+		require.NoError(t, state.SaveDefault())
+
+		privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, []byte(strings.Repeat("i", crypto.MinSeedLength)))
+		require.NoError(t, err)
+		data, err := encodeKey(formatPEM, privateKey, privateKey.PublicKey())
+		require.NoError(t, err)
+		require.NoError(t, rw.WriteFile("key.pem", data, 0600))
+
+		importFlags = flagsImport{
+			Format:   formatPEM,
+			SigAlgo:  "ECDSA_P256",
+			HashAlgo: "SHA3_256",
+			Account:  "emulator-account",
+			Address:  "01cf0e2f2f715450",
+		}
+		_, err = importKey([]string{"key.pem"}, command.GlobalFlags{ConfigPaths: []string{"flow.json"}}, util.NoLogger, rw, nil)
+		assert.EqualError(t, err, "account with name emulator-account already exists in the configuration")
+
+		state, err = flowkit.Load([]string{"flow.json"}, rw)
+		require.NoError(t, err)
+		account, err := state.Accounts().ByName("emulator-account")
+		require.NoError(t, err)
+		assert.Equal(t, config.KeyTypeFile, account.Key.Type())
+	})
+
+	t.Run("Fail unsupported format", func(t *testing.T) {
+		_, _, err := decodeKey("xml", nil, crypto.ECDSA_P256)
+		assert.EqualError(t, err, "format not supported. Valid formats: pem, jwk and der")
+	})
+}
+
+func Test_DeriveMany(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+	require.NoError(t, state.SaveDefault())
+
+	derive := srv.Mock.On("DerivePrivateKeyFromMnemonic", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	derive.Run(func(args mock.Arguments) {
+		privateKey, err := crypto.GeneratePrivateKey(args.Get(2).(crypto.SignatureAlgorithm), []byte(fmt.Sprintf("%-32s", args.String(3))))
+		require.NoError(t, err)
+		derive.Return(privateKey, nil)
+	})
+
+	created := 0
+	srv.CreateAccount.Run(func(args mock.Arguments) {
+		created++
+		srv.CreateAccount.Return(tests.NewAccountWithAddress(fmt.Sprintf("0%d", created)), flow.EmptyID, nil)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		deriveManyFlags = flagsDeriveMany{Mnemonic: "seed", Count: 3, Start: 2, BasePath: "m/44'/539'/0'/0", KeySigAlgo: "ECDSA_P256"}
+
+		result, err := deriveMany(nil, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+
+		keys := result.(*deriveManyResult).keys
+		require.Len(t, keys, 3)
+		assert.Equal(t, "m/44'/539'/0'/0/2", keys[0].path)
+		assert.Equal(t, "m/44'/539'/0'/0/4", keys[2].path)
+		assert.False(t, keys[0].privateKey.Equals(keys[1].privateKey))
+		assert.Equal(t, 0, created)
+	})
+
+	t.Run("Success create accounts", func(t *testing.T) {
+		deriveManyFlags = flagsDeriveMany{
+			Mnemonic:       "seed",
+			Count:          2,
+			BasePath:       "m/44'/539'/0'/0",
+			KeySigAlgo:     "ECDSA_P256",
+			HashAlgo:       "SHA3_256",
+			CreateAccounts: true,
+		}
+
+		result, err := deriveMany(nil, command.GlobalFlags{ConfigPaths: []string{"flow.json"}}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, 2, created)
+
+		state, err := flowkit.Load([]string{"flow.json"}, rw)
+		require.NoError(t, err)
+		for i, key := range result.(*deriveManyResult).keys {
+			account, err := state.Accounts().ByName(fmt.Sprintf("account-%d", i))
+			require.NoError(t, err)
+			assert.Equal(t, flow.HexToAddress(fmt.Sprintf("0%d", i+1)), account.Address)
+
+			assert.Equal(t, config.KeyTypeFile, account.Key.Type())
+			data, err := rw.ReadFile(fmt.Sprintf("account-%d.pkey", i))
+			require.NoError(t, err)
+			assert.Equal(t, key.privateKey.String(), string(data))
+		}
+	})
+
+	t.Run("Fail create accounts saves created accounts", func(t *testing.T) {
+		deriveManyFlags = flagsDeriveMany{
+			Mnemonic:       "seed",
+			Count:          3,
+			Start:          10,
+			BasePath:       "m/44'/539'/0'/0",
+			KeySigAlgo:     "ECDSA_P256",
+			HashAlgo:       "SHA3_256",
+			CreateAccounts: true,
+		}
+		created = 0
+		srv.CreateAccount.Run(func(args mock.Arguments) {
+			created++
+			if created == 2 {
+				srv.CreateAccount.Return(nil, flow.EmptyID, fmt.Errorf("emulator unavailable"))
+				return
+			}
+			srv.CreateAccount.Return(tests.NewAccountWithAddress(fmt.Sprintf("0%d", created)), flow.EmptyID, nil)
+		})
+
+		_, err := deriveMany(nil, command.GlobalFlags{ConfigPaths: []string{"flow.json"}}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "failed to create account for key m/44'/539'/0'/0/11: emulator unavailable, "+
+			"the 1 accounts created before the failure were added to the configuration")
+
+		state, err := flowkit.Load([]string{"flow.json"}, rw)
+		require.NoError(t, err)
+		account, err := state.Accounts().ByName("account-10")
+		require.NoError(t, err)
+		assert.Equal(t, flow.HexToAddress("01"), account.Address)
+		_, err = state.Accounts().ByName("account-11")
+		assert.Error(t, err)
+	})
+
+	t.Run("Fail create accounts existing name", func(t *testing.T) {
+		deriveManyFlags = flagsDeriveMany{
+			Mnemonic:       "seed",
+			Count:          2,
+			Start:          9,
+			BasePath:       "m/44'/539'/0'/0",
+			KeySigAlgo:     "ECDSA_P256",
+			HashAlgo:       "SHA3_256",
+			CreateAccounts: true,
+		}
+		created = 0
+
+		_, err := deriveMany(nil, command.GlobalFlags{ConfigPaths: []string{"flow.json"}}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "account with name account-10 already exists in the configuration")
+		assert.Equal(t, 0, created)
+	})
+
+	t.Run("Fail missing mnemonic", func(t *testing.T) {
+		deriveManyFlags = flagsDeriveMany{Count: 1}
+		_, err := deriveMany(nil, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "mnemonic must be provided with the mnemonic flag")
+	})
+}
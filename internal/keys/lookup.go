@@ -0,0 +1,344 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package keys
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/config"
+	"github.com/onflow/flowkit/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsLookup struct {
+	Encoding string `default:"hex" flag:"encoding" info:"Encoding of the public key. Valid values: hex, rlp, pem"`
+	SigAlgo  string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm of the PEM encoded public key"`
+	FromFile string `default:"" flag:"from-file" info:"Load public key from file"`
+	Indexer  string `default:"" flag:"indexer" info:"URL of a key indexer endpoint queried with the hex public key, the accounts in the configuration are scanned if not provided"`
+}
+
+var lookupFlags = flagsLookup{}
+
+var lookupCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "lookup <public key>",
+		Short: "Find the accounts which have the public key",
+		Example: `flow keys lookup 0x84d716c14b051ad6b001624f738f5d302636e6b07cc75e4530af7776a4368a2b586dbefc0564ee28384c2696f178cbed52e62811bcc9ecb59568c996d342db24
+
+flow keys lookup --encoding pem --from-file key.pem
+
+flow keys lookup 84d716c1...d342db24 --indexer https://key-indexer.example.com/key`,
+		Args: cobra.MaximumNArgs(1),
+	},
+	Flags: &lookupFlags,
+	RunS:  lookup,
+}
+
+// keyMatch is an account key which has the looked up public key.
+type keyMatch struct {
+	network string
+	name    string
+	address flowsdk.Address
+	index   int
+	weight  int
+	revoked bool
+}
+
+func lookup(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	encoded := ""
+	if len(args) > 0 {
+		encoded = args[0]
+	}
+	if encoded != "" && lookupFlags.FromFile != "" {
+		return nil, fmt.Errorf("can not pass both command argument and from file flag")
+	}
+	if encoded == "" && lookupFlags.FromFile == "" {
+		return nil, fmt.Errorf("provide argument for public key or use from file flag")
+	}
+
+	if lookupFlags.FromFile != "" {
+		e, err := state.ReaderWriter().ReadFile(lookupFlags.FromFile)
+		if err != nil {
+			return nil, err
+		}
+		encoded = strings.TrimSpace(string(e))
+	}
+
+	publicKey, err := decodeLookupKey(encoded, lookupFlags.Encoding, lookupFlags.SigAlgo)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.StartProgress("Looking up accounts with the public key...")
+	defer logger.StopProgress()
+
+	var matches []keyMatch
+	if lookupFlags.Indexer != "" {
+		matches, err = lookupIndexer(lookupFlags.Indexer, publicKey)
+	} else {
+		matches, err = lookupConfiguredAccounts(flow, state, logger, publicKey)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &lookupResult{publicKey: publicKey, matches: matches}, nil
+}
+
+// decodeLookupKey returns the hex encoded raw public key, decoding the RLP and PEM encodings the same way as keys decode.
+func decodeLookupKey(encoded string, encoding string, sigAlgo string) (string, error) {
+	switch strings.ToLower(encoding) {
+	case "hex":
+		raw, err := hex.DecodeString(strings.TrimPrefix(encoded, "0x"))
+		if err != nil {
+			return "", fmt.Errorf("failed to decode public key: %w", err)
+		}
+		return hex.EncodeToString(raw), nil
+	case "rlp":
+		accountKey, err := decodeRLP(encoded)
+		if err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(accountKey.PublicKey.Encode()), nil
+	case "pem":
+		algo := crypto.StringToSignatureAlgorithm(sigAlgo)
+		if algo == crypto.UnknownSignatureAlgorithm {
+			return "", fmt.Errorf("invalid signature algorithm: %s", sigAlgo)
+		}
+		accountKey, err := decodePEM(encoded, algo)
+		if err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(accountKey.PublicKey.Encode()), nil
+	default:
+		return "", fmt.Errorf("encoding type not supported. Valid encoding: hex, RLP and PEM")
+	}
+}
+
+// lookupConfiguredAccounts fetches the accounts in the configuration from every network whose chain
+// the account address belongs to and returns the keys matching the public key.
+func lookupConfiguredAccounts(
+	flow flowkit.Services,
+	state *flowkit.State,
+	logger output.Logger,
+	publicKey string,
+) ([]keyMatch, error) {
+	chains := map[string]flowsdk.ChainID{
+		config.EmulatorNetwork.Name: flowsdk.Emulator,
+		config.TestnetNetwork.Name:  flowsdk.Testnet,
+		config.MainnetNetwork.Name:  flowsdk.Mainnet,
+	}
+
+	matches := make([]keyMatch, 0)
+	for _, network := range *state.Networks() {
+		network := network
+		var services flowkit.Services
+
+		for _, account := range *state.Accounts() {
+			if chain, ok := chains[network.Name]; ok && !account.Address.IsValid(chain) {
+				continue
+			}
+
+			if services == nil {
+				var err error
+				services, err = util.ServicesForNetwork(flow, state, &network)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			onChain, err := services.GetAccount(context.Background(), account.Address)
+			if err != nil {
+				logger.Info(fmt.Sprintf(
+					"%s could not fetch account %s on network %s: %s",
+					output.WarningEmoji(),
+					account.Name,
+					network.Name,
+					err.Error(),
+				))
+				continue
+			}
+
+			for _, key := range onChain.Keys {
+				if hex.EncodeToString(key.PublicKey.Encode()) != publicKey {
+					continue
+				}
+				matches = append(matches, keyMatch{
+					network: network.Name,
+					name:    account.Name,
+					address: account.Address,
+					index:   key.Index,
+					weight:  key.Weight,
+					revoked: key.Revoked,
+				})
+			}
+		}
+	}
+
+	return matches, nil
+}
+
+// indexerResponse is the response of a key indexer endpoint listing the accounts with the public key.
+type indexerResponse struct {
+	PublicKey string `json:"publicKey"`
+	Accounts  []struct {
+		Address   string `json:"address"`
+		KeyID     int    `json:"keyId"`
+		Weight    int    `json:"weight"`
+		IsRevoked bool   `json:"isRevoked"`
+	} `json:"accounts"`
+}
+
+// lookupIndexer queries the key indexer endpoint with the public key appended to the URL path.
+func lookupIndexer(url string, publicKey string) ([]keyMatch, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(fmt.Sprintf("%s/%s", strings.TrimSuffix(url, "/"), publicKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query key indexer: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return []keyMatch{}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("key indexer returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var indexed indexerResponse
+	if err := json.Unmarshal(body, &indexed); err != nil {
+		return nil, fmt.Errorf("invalid key indexer response: %w", err)
+	}
+
+	matches := make([]keyMatch, 0, len(indexed.Accounts))
+	for _, account := range indexed.Accounts {
+		matches = append(matches, keyMatch{
+			network: "indexer",
+			address: flowsdk.HexToAddress(account.Address),
+			index:   account.KeyID,
+			weight:  account.Weight,
+			revoked: account.IsRevoked,
+		})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].address != matches[j].address {
+			return matches[i].address.String() < matches[j].address.String()
+		}
+		return matches[i].index < matches[j].index
+	})
+
+	return matches, nil
+}
+
+type lookupResult struct {
+	publicKey string
+	matches   []keyMatch
+}
+
+func (r *lookupResult) JSON() any {
+	matches := make([]any, 0, len(r.matches))
+	for _, match := range r.matches {
+		m := map[string]any{
+			"network": match.network,
+			"address": fmt.Sprintf("0x%s", match.address),
+			"index":   match.index,
+			"weight":  match.weight,
+			"revoked": match.revoked,
+		}
+		if match.name != "" {
+			m["name"] = match.name
+		}
+		matches = append(matches, m)
+	}
+
+	return map[string]any{
+		"publicKey": r.publicKey,
+		"accounts":  matches,
+	}
+}
+
+func (r *lookupResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Public Key\t 0x%s\n", r.publicKey)
+	if len(r.matches) == 0 {
+		_, _ = fmt.Fprintf(writer, "\nNo accounts found with the public key.\n")
+		_ = writer.Flush()
+		return b.String()
+	}
+
+	_, _ = fmt.Fprintf(writer, "\nNetwork\t Account\t Address\t Key Index\t Weight\t Revoked\n")
+	for _, match := range r.matches {
+		name := match.name
+		if name == "" {
+			name = "-"
+		}
+		_, _ = fmt.Fprintf(
+			writer,
+			"%s\t %s\t 0x%s\t %d\t %d\t %t\n",
+			match.network,
+			name,
+			match.address,
+			match.index,
+			match.weight,
+			match.revoked,
+		)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *lookupResult) Oneliner() string {
+	accounts := make([]string, 0, len(r.matches))
+	for _, match := range r.matches {
+		accounts = append(accounts, fmt.Sprintf("0x%s@%s#%d", match.address, match.network, match.index))
+	}
+
+	return fmt.Sprintf("Public Key: 0x%s, Accounts: %s", r.publicKey, strings.Join(accounts, ", "))
+}
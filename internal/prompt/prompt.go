@@ -278,10 +278,7 @@ func addAnotherContractToDeploymentPrompt() bool {
 // returns true if the user wishes to continue with the deployment and false otherwise
 func ShowContractDiffPrompt(logger output.Logger) func([]byte, []byte) bool {
 	return func(newContract []byte, existingContract []byte) bool {
-		dmp := diffmatchpatch.New()
-		diffs := dmp.DiffMain(string(newContract), string(existingContract), false)
-		diffString := dmp.DiffPrettyText(diffs)
-		logger.Info(diffString)
+		logger.Info(ContractDiff(newContract, existingContract))
 
 		deployPrompt := promptui.Prompt{
 			Label:     "Do you wish to deploy this contract?",
@@ -297,6 +294,13 @@ func ShowContractDiffPrompt(logger output.Logger) func([]byte, []byte) bool {
 	}
 }
 
+// ContractDiff renders the colored differences between the new contract and the existing contract
+func ContractDiff(newContract []byte, existingContract []byte) string {
+	dmp := diffmatchpatch.New()
+	diffs := dmp.DiffMain(string(newContract), string(existingContract), false)
+	return dmp.DiffPrettyText(diffs)
+}
+
 type AccountData struct {
 	Name     string
 	Address  string
@@ -807,6 +811,38 @@ func GenericBoolPrompt(msg string) bool {
 	return result == "Yes"
 }
 
+// PassphrasePrompt asks for a passphrase without echoing it, optionally asking to confirm it
+func PassphrasePrompt(label string, confirm bool) (string, error) {
+	passphrasePrompt := promptui.Prompt{
+		Label: label,
+		Mask:  '*',
+	}
+	passphrase, err := passphrasePrompt.Run()
+	if err == promptui.ErrInterrupt {
+		os.Exit(-1)
+	}
+	if err != nil || !confirm {
+		return passphrase, err
+	}
+
+	confirmPrompt := promptui.Prompt{
+		Label: "Confirm passphrase",
+		Mask:  '*',
+		Validate: func(s string) error {
+			if s != passphrase {
+				return fmt.Errorf("passphrases do not match")
+			}
+			return nil
+		},
+	}
+	_, err = confirmPrompt.Run()
+	if err == promptui.ErrInterrupt {
+		os.Exit(-1)
+	}
+
+	return passphrase, err
+}
+
 func GenericSelect(items []string, message string) string {
 	prompt := promptui.Select{
 		Label: message,
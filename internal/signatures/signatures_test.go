@@ -19,9 +19,15 @@
 package signatures
 
 import (
+	"fmt"
 	"testing"
 
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flowkit/tests"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/util"
@@ -48,6 +54,9 @@ func Test_Verify(t *testing.T) {
 			err  string
 		}{{
 			args: []string{"invalid", "invalid"},
+			err:  "signature and public key arguments are required unless the address flag is provided",
+		}, {
+			args: []string{"invalid", "invalid", "0xaaaa"},
 			err:  "invalid message signature: encoding/hex: invalid byte: U+0069 'i'",
 		}, {
 			args: []string{"invalid", "0xaaaa", "invalid"},
@@ -66,6 +75,62 @@ func Test_Verify(t *testing.T) {
 
 }
 
+func Test_VerifyAccount(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+
+	publicKey, err := crypto.DecodePublicKeyHex(crypto.ECDSA_P256, "ab70e9e341a38861fd7f9fb1cda4c560465cfeb3ce4abcd2be552550c85ebbef9a2a9cac731c6bfa73b10c701c93038f0c18253487d4962d3bc6d5291f9c5eae")
+	require.NoError(t, err)
+	signature := "f80f6007dbe6795bcf343e5586d40d0ba26a6c1d7edda5653cbdb377c9c20034cdbf899bb20fa2388d4993f6c88b5c97cbe05963d6d9799e6868902c2c14bc22"
+
+	account := tests.NewAccountWithAddress("01cf0e2f2f715450")
+	account.Keys = []*flow.AccountKey{
+		{Index: 0, PublicKey: publicKey, SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256, Weight: 600},
+		{Index: 1, PublicKey: publicKey, SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256, Weight: 500, Revoked: true},
+		{Index: 2, PublicKey: publicKey, SigAlgo: crypto.ECDSA_P256, HashAlgo: crypto.SHA3_256, Weight: 500},
+	}
+	srv.GetAccount.Run(func(args mock.Arguments) {
+		srv.GetAccount.Return(account, nil)
+	})
+
+	t.Run("Success threshold reached", func(t *testing.T) {
+		verifyFlags = flagsVerify{Address: "0x01cf0e2f2f715450", Signatures: []string{"0:" + signature, "2:0x" + signature}}
+
+		result, err := verify([]string{"test signature"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		verification := result.(*accountVerificationResult)
+		assert.True(t, verification.valid())
+		assert.Equal(t, 1100, verification.totalWeight)
+	})
+
+	t.Run("Success threshold not reached", func(t *testing.T) {
+		verifyFlags = flagsVerify{
+			Address:    "0x01cf0e2f2f715450",
+			Signatures: []string{"0:" + signature, "0:" + signature, "1:" + signature, "2:aaaa", "5:" + signature},
+		}
+
+		result, err := verify([]string{"test signature"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		verification := result.(*accountVerificationResult)
+		assert.False(t, verification.valid())
+		assert.Equal(t, 600, verification.totalWeight)
+		assert.Equal(t, "key already counted", verification.signatures[1].reason)
+		assert.Equal(t, "key is revoked", verification.signatures[2].reason)
+		assert.False(t, verification.signatures[3].valid)
+		assert.Equal(t, "key does not exist", verification.signatures[4].reason)
+	})
+
+	t.Run("Fail invalid signature format", func(t *testing.T) {
+		verifyFlags = flagsVerify{Address: "0x01cf0e2f2f715450", Signatures: []string{signature}}
+
+		_, err := verify([]string{"test signature"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, fmt.Sprintf("invalid signature %s, must be in the <key index>:<signature> format", signature))
+	})
+
+	verifyFlags = flagsVerify{SigAlgo: "ECDSA_P256", HashAlgo: "SHA3_256"}
+}
+
 func Test_Sign(t *testing.T) {
 	srv, state, _ := util.TestMocks(t)
 
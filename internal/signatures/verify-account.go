@@ -0,0 +1,222 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package signatures
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/output"
+
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// keySignature is a signature created by the account key with the index.
+type keySignature struct {
+	index     int
+	signature []byte
+	weight    int
+	revoked   bool
+	valid     bool
+	reason    string
+}
+
+// parseKeySignatures parses the signatures provided in the <key index>:<signature> format.
+func parseKeySignatures(values []string) ([]keySignature, error) {
+	signatures := make([]keySignature, 0, len(values))
+	for _, value := range values {
+		index, signature, found := strings.Cut(value, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid signature %s, must be in the <key index>:<signature> format", value)
+		}
+
+		keyIndex, err := strconv.Atoi(index)
+		if err != nil || keyIndex < 0 {
+			return nil, fmt.Errorf("invalid key index %s, must be a positive number", index)
+		}
+
+		sig, err := hex.DecodeString(strings.TrimPrefix(signature, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid message signature: %w", err)
+		}
+
+		signatures = append(signatures, keySignature{index: keyIndex, signature: sig})
+	}
+
+	return signatures, nil
+}
+
+// verifyAccount verifies the signatures against the account keys using the signature and hashing
+// algorithm of each key, and checks the combined weight of the keys with valid signatures reaches
+// the weight threshold, revoked keys and repeated keys are not counted.
+func verifyAccount(
+	message string,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (*accountVerificationResult, error) {
+	address := flowsdk.HexToAddress(verifyFlags.Address)
+	if state != nil {
+		if account, err := state.Accounts().ByName(verifyFlags.Address); err == nil {
+			address = account.Address
+		}
+	}
+	if address == flowsdk.EmptyAddress {
+		return nil, fmt.Errorf("invalid address or account name: %s", verifyFlags.Address)
+	}
+
+	signatures, err := parseKeySignatures(verifyFlags.Signatures)
+	if err != nil {
+		return nil, err
+	}
+	if len(signatures) == 0 {
+		return nil, fmt.Errorf("at least one signature must be provided with the signature flag")
+	}
+
+	logger.StartProgress(fmt.Sprintf("Fetching keys of account 0x%s...", address))
+	defer logger.StopProgress()
+
+	account, err := flow.GetAccount(context.Background(), address)
+	if err != nil {
+		return nil, err
+	}
+
+	signedMessage := []byte(message)
+	if verifyFlags.UserMessage {
+		signedMessage = append(flowsdk.UserDomainTag[:], signedMessage...)
+	}
+
+	totalWeight := 0
+	counted := make(map[int]bool)
+	for i, sig := range signatures {
+		key := findKey(account, sig.index)
+		if key == nil {
+			signatures[i].reason = "key does not exist"
+			continue
+		}
+
+		signatures[i].weight = key.Weight
+		signatures[i].revoked = key.Revoked
+
+		hasher, err := crypto.NewHasher(key.HashAlgo)
+		if err != nil {
+			return nil, err
+		}
+
+		valid, err := key.PublicKey.Verify(sig.signature, signedMessage, hasher)
+		if err != nil {
+			signatures[i].reason = err.Error()
+			continue
+		}
+		signatures[i].valid = valid
+
+		switch {
+		case !valid:
+			signatures[i].reason = "invalid signature"
+		case key.Revoked:
+			signatures[i].reason = "key is revoked"
+		case counted[key.Index]:
+			signatures[i].reason = "key already counted"
+		default:
+			counted[key.Index] = true
+			totalWeight += key.Weight
+		}
+	}
+
+	return &accountVerificationResult{
+		address:     address,
+		message:     message,
+		signatures:  signatures,
+		totalWeight: totalWeight,
+	}, nil
+}
+
+func findKey(account *flowsdk.Account, index int) *flowsdk.AccountKey {
+	for _, key := range account.Keys {
+		if key.Index == index {
+			return key
+		}
+	}
+
+	return nil
+}
+
+type accountVerificationResult struct {
+	address     flowsdk.Address
+	message     string
+	signatures  []keySignature
+	totalWeight int
+}
+
+func (r *accountVerificationResult) valid() bool {
+	return r.totalWeight >= flowsdk.AccountKeyWeightThreshold
+}
+
+func (r *accountVerificationResult) JSON() any {
+	signatures := make([]any, 0, len(r.signatures))
+	for _, sig := range r.signatures {
+		signatures = append(signatures, map[string]any{
+			"keyIndex":  sig.index,
+			"signature": fmt.Sprintf("%x", sig.signature),
+			"weight":    sig.weight,
+			"revoked":   sig.revoked,
+			"valid":     sig.valid,
+			"reason":    sig.reason,
+		})
+	}
+
+	return map[string]any{
+		"valid":       r.valid(),
+		"address":     fmt.Sprintf("0x%s", r.address),
+		"message":     r.message,
+		"totalWeight": r.totalWeight,
+		"threshold":   flowsdk.AccountKeyWeightThreshold,
+		"signatures":  signatures,
+	}
+}
+
+func (r *accountVerificationResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Valid \t %v\n", r.valid())
+	_, _ = fmt.Fprintf(writer, "Address \t 0x%s\n", r.address)
+	_, _ = fmt.Fprintf(writer, "Message \t %s\n", r.message)
+	_, _ = fmt.Fprintf(writer, "Weight \t %d/%d\n", r.totalWeight, flowsdk.AccountKeyWeightThreshold)
+
+	_, _ = fmt.Fprintf(writer, "\nKey Index \t Weight \t Revoked \t Valid \t Note\n")
+	for _, sig := range r.signatures {
+		_, _ = fmt.Fprintf(writer, "%d \t %d \t %v \t %v \t %s\n", sig.index, sig.weight, sig.revoked, sig.valid, sig.reason)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *accountVerificationResult) Oneliner() string {
+	return fmt.Sprintf("valid: %v, address: 0x%s, weight: %d", r.valid(), r.address, r.totalWeight)
+}
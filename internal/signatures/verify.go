@@ -35,18 +35,23 @@ import (
 )
 
 type flagsVerify struct {
-	SigAlgo  string `flag:"sig-algo" default:"ECDSA_P256" info:"Signature algorithm used to create the public key"`
-	HashAlgo string `flag:"hash-algo" default:"SHA3_256" info:"Hashing algorithm used to create signature"`
+	SigAlgo     string   `flag:"sig-algo" default:"ECDSA_P256" info:"Signature algorithm used to create the public key"`
+	HashAlgo    string   `flag:"hash-algo" default:"SHA3_256" info:"Hashing algorithm used to create signature"`
+	Address     string   `flag:"address" default:"" info:"Address or account name to verify the signatures against the account keys"`
+	Signatures  []string `flag:"signature" default:"" info:"Signature created by an account key in the <key index>:<signature> format"`
+	UserMessage bool     `flag:"user-message" default:"false" info:"Verify the signatures as user message signatures prefixed with the user domain tag"`
 }
 
 var verifyFlags = flagsVerify{}
 
 var verifyCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "verify <message> <signature> <public key>",
-		Short:   "Verify the signature",
-		Example: "flow signatures verify 'The quick brown fox jumps over the lazy dog' 99fa...25b af3...52d",
-		Args:    cobra.ExactArgs(3),
+		Use:   "verify <message> [<signature> <public key>]",
+		Short: "Verify the signature",
+		Example: `flow signatures verify 'The quick brown fox jumps over the lazy dog' 99fa...25b af3...52d
+
+flow signatures verify 'The quick brown fox jumps over the lazy dog' --address 0x01cf0e2f2f715450 --signature 0:99fa...25b --signature 2:b3c...e1a`,
+		Args: cobra.RangeArgs(1, 3),
 	},
 	Flags: &verifyFlags,
 	RunS:  verify,
@@ -55,10 +60,20 @@ var verifyCommand = &command.Command{
 func verify(
 	args []string,
 	_ command.GlobalFlags,
-	_ output.Logger,
-	_ flowkit.Services,
-	_ *flowkit.State,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
 ) (command.Result, error) {
+	if verifyFlags.Address != "" {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("only the message argument is accepted when verifying account signatures")
+		}
+		return verifyAccount(args[0], logger, flow, state)
+	}
+	if len(args) != 3 {
+		return nil, fmt.Errorf("signature and public key arguments are required unless the address flag is provided")
+	}
+
 	message := []byte(args[0])
 
 	sig, err := hex.DecodeString(strings.ReplaceAll(args[1], "0x", ""))
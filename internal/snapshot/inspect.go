@@ -0,0 +1,292 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/state/protocol/inmem"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+var inspectCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "inspect <file>",
+		Short:   "Print the contents of a protocol snapshot",
+		Example: "flow snapshot inspect /tmp/snapshot.json",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &struct{}{},
+	Run:   inspect,
+}
+
+func inspect(
+	args []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	reader flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	snapshot, err := readSnapshot(reader, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return newInspectResult(snapshot)
+}
+
+// readSnapshot decodes the protocol snapshot file in the JSON encoding returned by the access node.
+func readSnapshot(reader flowkit.ReaderWriter, path string) (*inmem.Snapshot, error) {
+	data, err := reader.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read protocol snapshot file %s: %w", path, err)
+	}
+
+	var encodable inmem.EncodableSnapshot
+	err = json.Unmarshal(data, &encodable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode protocol snapshot: %w", err)
+	}
+
+	return inmem.SnapshotFromEncodable(encodable), nil
+}
+
+// roleSummary is the number of nodes and their total weight for a role in the identity table.
+type roleSummary struct {
+	role   flow.Role
+	nodes  int
+	weight uint64
+}
+
+// epochSummary contains the epoch counter and view range.
+type epochSummary struct {
+	counter   uint64
+	firstView uint64
+	finalView uint64
+}
+
+type inspectResult struct {
+	chainID              flow.ChainID
+	sporkID              flow.Identifier
+	sporkRootBlockHeight uint64
+	protocolVersion      uint
+	root                 *flow.Header
+	sealedHeight         uint64
+	finalizedHeight      uint64
+	segmentBlocks        int
+	extraBlocks          int
+	phase                flow.EpochPhase
+	currentEpoch         epochSummary
+	nextEpoch            *epochSummary
+	roles                []roleSummary
+}
+
+func newInspectResult(snapshot protocol.Snapshot) (*inspectResult, error) {
+	params := snapshot.Params()
+	result := &inspectResult{
+		chainID:              params.ChainID(),
+		sporkID:              params.SporkID(),
+		sporkRootBlockHeight: params.SporkRootBlockHeight(),
+		protocolVersion:      params.ProtocolVersion(),
+	}
+
+	head, err := snapshot.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the snapshot root block: %w", err)
+	}
+	result.root = head
+
+	segment, err := snapshot.SealingSegment()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the sealing segment: %w", err)
+	}
+	result.sealedHeight = segment.Sealed().Header.Height
+	result.finalizedHeight = segment.Highest().Header.Height
+	result.segmentBlocks = len(segment.Blocks)
+	result.extraBlocks = len(segment.ExtraBlocks)
+
+	result.phase, err = snapshot.Phase()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the epoch phase: %w", err)
+	}
+
+	current, err := newEpochSummary(snapshot.Epochs().Current())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the current epoch: %w", err)
+	}
+	result.currentEpoch = *current
+
+	// the next epoch is only known after the epoch setup phase started
+	if result.phase != flow.EpochPhaseStaking {
+		result.nextEpoch, err = newEpochSummary(snapshot.Epochs().Next())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the next epoch: %w", err)
+		}
+	}
+
+	identities, err := snapshot.Identities(filter.Any)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the identity table: %w", err)
+	}
+
+	roles := make(map[flow.Role]*roleSummary)
+	for _, identity := range identities {
+		summary, ok := roles[identity.Role]
+		if !ok {
+			summary = &roleSummary{role: identity.Role}
+			roles[identity.Role] = summary
+		}
+		summary.nodes++
+		summary.weight += identity.InitialWeight
+	}
+	for _, summary := range roles {
+		result.roles = append(result.roles, *summary)
+	}
+	sort.Slice(result.roles, func(i, j int) bool {
+		return result.roles[i].role < result.roles[j].role
+	})
+
+	return result, nil
+}
+
+func newEpochSummary(epoch protocol.Epoch) (*epochSummary, error) {
+	counter, err := epoch.Counter()
+	if err != nil {
+		return nil, err
+	}
+	firstView, err := epoch.FirstView()
+	if err != nil {
+		return nil, err
+	}
+	finalView, err := epoch.FinalView()
+	if err != nil {
+		return nil, err
+	}
+
+	return &epochSummary{
+		counter:   counter,
+		firstView: firstView,
+		finalView: finalView,
+	}, nil
+}
+
+func (e epochSummary) JSON() any {
+	return map[string]any{
+		"counter":   e.counter,
+		"firstView": e.firstView,
+		"finalView": e.finalView,
+	}
+}
+
+func (r *inspectResult) JSON() any {
+	roles := make([]any, 0, len(r.roles))
+	for _, role := range r.roles {
+		roles = append(roles, map[string]any{
+			"role":   role.role.String(),
+			"nodes":  role.nodes,
+			"weight": role.weight,
+		})
+	}
+
+	result := map[string]any{
+		"chainId":              r.chainID.String(),
+		"sporkId":              r.sporkID.String(),
+		"sporkRootBlockHeight": r.sporkRootBlockHeight,
+		"protocolVersion":      r.protocolVersion,
+		"rootBlock": map[string]any{
+			"id":        r.root.ID().String(),
+			"height":    r.root.Height,
+			"view":      r.root.View,
+			"timestamp": r.root.Timestamp,
+		},
+		"sealingSegment": map[string]any{
+			"sealedHeight":    r.sealedHeight,
+			"finalizedHeight": r.finalizedHeight,
+			"blocks":          r.segmentBlocks,
+			"extraBlocks":     r.extraBlocks,
+		},
+		"epochPhase":   r.phase.String(),
+		"currentEpoch": r.currentEpoch.JSON(),
+		"identities":   roles,
+	}
+	if r.nextEpoch != nil {
+		result["nextEpoch"] = r.nextEpoch.JSON()
+	}
+
+	return result
+}
+
+func (r *inspectResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Chain ID\t%s\n", r.chainID)
+	_, _ = fmt.Fprintf(writer, "Spork ID\t%s\n", r.sporkID)
+	_, _ = fmt.Fprintf(writer, "Spork Root Height\t%d\n", r.sporkRootBlockHeight)
+	_, _ = fmt.Fprintf(writer, "Protocol Version\t%d\n", r.protocolVersion)
+
+	_, _ = fmt.Fprintf(writer, "\nRoot Block\n")
+	_, _ = fmt.Fprintf(writer, "    ID\t%s\n", r.root.ID())
+	_, _ = fmt.Fprintf(writer, "    Height\t%d\n", r.root.Height)
+	_, _ = fmt.Fprintf(writer, "    View\t%d\n", r.root.View)
+	_, _ = fmt.Fprintf(writer, "    Timestamp\t%s\n", r.root.Timestamp.UTC().Format(time.RFC3339))
+
+	_, _ = fmt.Fprintf(writer, "\nSealing Segment\n")
+	_, _ = fmt.Fprintf(writer, "    Sealed Height\t%d\n", r.sealedHeight)
+	_, _ = fmt.Fprintf(writer, "    Finalized Height\t%d\n", r.finalizedHeight)
+	_, _ = fmt.Fprintf(writer, "    Blocks\t%d\n", r.segmentBlocks)
+	_, _ = fmt.Fprintf(writer, "    Extra Blocks\t%d\n", r.extraBlocks)
+
+	_, _ = fmt.Fprintf(writer, "\nEpoch\n")
+	_, _ = fmt.Fprintf(writer, "    Phase\t%s\n", r.phase)
+	_, _ = fmt.Fprintf(writer, "    Current Counter\t%d\n", r.currentEpoch.counter)
+	_, _ = fmt.Fprintf(writer, "    Current Views\t%d to %d\n", r.currentEpoch.firstView, r.currentEpoch.finalView)
+	if r.nextEpoch != nil {
+		_, _ = fmt.Fprintf(writer, "    Next Counter\t%d\n", r.nextEpoch.counter)
+		_, _ = fmt.Fprintf(writer, "    Next Views\t%d to %d\n", r.nextEpoch.firstView, r.nextEpoch.finalView)
+	}
+
+	_, _ = fmt.Fprintf(writer, "\nIdentities\n")
+	for _, role := range r.roles {
+		_, _ = fmt.Fprintf(writer, "    %s\t%d nodes, weight %d\n", role.role, role.nodes, role.weight)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *inspectResult) Oneliner() string {
+	return fmt.Sprintf(
+		"Chain: %s, Root Height: %d, Sealed Height: %d, Epoch: %d, Phase: %s",
+		r.chainID, r.root.Height, r.sealedHeight, r.currentEpoch.counter, r.phase,
+	)
+}
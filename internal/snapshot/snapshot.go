@@ -32,6 +32,8 @@ var Cmd = &cobra.Command{
 
 func init() {
 	saveCommand.AddToParent(Cmd)
+	inspectCommand.AddToParent(Cmd)
+	verifyCommand.AddToParent(Cmd)
 }
 
 // saveResult represents the result of the snapshot save command.
@@ -0,0 +1,83 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package snapshot
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_Snapshot(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	snapshot := unittest.RootSnapshotFixture(unittest.CompleteIdentitySet())
+	data, err := json.Marshal(snapshot.Encodable())
+	require.NoError(t, err)
+	require.NoError(t, rw.WriteFile("snapshot.json", data, 0644))
+
+	t.Run("Success inspect", func(t *testing.T) {
+		result, err := inspect([]string{"snapshot.json"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+
+		r := result.(*inspectResult)
+		head, err := snapshot.Head()
+		require.NoError(t, err)
+		assert.Equal(t, head.ID(), r.root.ID())
+		assert.Equal(t, flow.Emulator, r.chainID)
+		assert.Equal(t, flow.EpochPhaseStaking, r.phase)
+		assert.Len(t, r.roles, 4)
+		assert.Contains(t, r.String(), "Sealing Segment")
+	})
+
+	t.Run("Success verify", func(t *testing.T) {
+		result := verifySnapshot(snapshot)
+		require.Len(t, result.checks, 3)
+		assert.NoError(t, result.checks[0].err)
+		assert.NoError(t, result.checks[1].err)
+		// the fixture quorum certificate is not signed by the participants
+		assert.ErrorContains(t, result.checks[2].err, "invalid root QC")
+		assert.Equal(t, "snapshot invalid: quorum certificates", result.Oneliner())
+	})
+
+	t.Run("Fail verify inconsistent snapshot", func(t *testing.T) {
+		encodable := snapshot.Encodable()
+		encodable.QuorumCertificate.BlockID = unittest.IdentifierFixture()
+		data, err := json.Marshal(encodable)
+		require.NoError(t, err)
+		require.NoError(t, rw.WriteFile("invalid.json", data, 0644))
+
+		_, err = verify([]string{"invalid.json"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.ErrorContains(t, err, "qc is for wrong block")
+	})
+
+	t.Run("Fail invalid file", func(t *testing.T) {
+		require.NoError(t, rw.WriteFile("broken.json", []byte("{"), 0644))
+
+		_, err := inspect([]string{"broken.json"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.ErrorContains(t, err, "failed to decode protocol snapshot")
+	})
+}
@@ -0,0 +1,150 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package snapshot
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/state/protocol/badger"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/output"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+var verifyCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "verify <file>",
+		Short:   "Check the internal consistency of a protocol snapshot",
+		Example: "flow snapshot verify /tmp/snapshot.json",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &struct{}{},
+	Run:   verify,
+}
+
+func verify(
+	args []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	reader flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	snapshot, err := readSnapshot(reader, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	result := verifySnapshot(snapshot)
+	if !result.valid() {
+		return nil, fmt.Errorf("protocol snapshot is invalid:\n%s", result.String())
+	}
+
+	return result, nil
+}
+
+// snapshotCheck is the outcome of a single consistency check.
+type snapshotCheck struct {
+	name string
+	err  error
+}
+
+// verifySnapshot runs the consistency checks nodes perform before bootstrapping from a root snapshot.
+func verifySnapshot(snapshot protocol.Snapshot) *verifyResult {
+	checks := []snapshotCheck{{name: "sealing segment"}, {name: "sealed result and root block"}, {name: "quorum certificates"}}
+
+	segment, err := snapshot.SealingSegment()
+	if err == nil {
+		err = segment.Validate()
+	}
+	checks[0].err = err
+	checks[1].err = badger.IsValidRootSnapshot(snapshot, true)
+	checks[2].err = badger.IsValidRootSnapshotQCs(snapshot)
+
+	return &verifyResult{checks: checks}
+}
+
+type verifyResult struct {
+	checks []snapshotCheck
+}
+
+func (r *verifyResult) valid() bool {
+	for _, check := range r.checks {
+		if check.err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *verifyResult) JSON() any {
+	checks := make([]any, 0, len(r.checks))
+	for _, check := range r.checks {
+		result := map[string]any{
+			"check": check.name,
+			"valid": check.err == nil,
+		}
+		if check.err != nil {
+			result["error"] = check.err.Error()
+		}
+		checks = append(checks, result)
+	}
+
+	return map[string]any{
+		"valid":  r.valid(),
+		"checks": checks,
+	}
+}
+
+func (r *verifyResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	for _, check := range r.checks {
+		if check.err != nil {
+			_, _ = fmt.Fprintf(writer, "%s %s\t%s\n", output.ErrorEmoji(), check.name, check.err)
+		} else {
+			_, _ = fmt.Fprintf(writer, "%s %s\tvalid\n", output.OkEmoji(), check.name)
+		}
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *verifyResult) Oneliner() string {
+	failed := make([]string, 0)
+	for _, check := range r.checks {
+		if check.err != nil {
+			failed = append(failed, check.name)
+		}
+	}
+
+	if len(failed) == 0 {
+		return "snapshot valid"
+	}
+	return fmt.Sprintf("snapshot invalid: %s", strings.Join(failed, ", "))
+}
@@ -0,0 +1,130 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package status
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/onflow/flow-go/utils/grpcutils"
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/onflow/flowkit/config"
+)
+
+// blockHeader is the part of the block header used to report the chain progress.
+type blockHeader struct {
+	height    uint64
+	timestamp time.Time
+}
+
+// nodeVersion is the software and spork information reported by the access node.
+type nodeVersion struct {
+	semver               string
+	commit               string
+	protocolVersion      uint64
+	sporkRootBlockHeight uint64
+	nodeRootBlockHeight  uint64
+}
+
+// accessClient queries the access node of a network for the status details.
+type accessClient interface {
+	Ping(context.Context) error
+	LatestBlockHeader(ctx context.Context, sealed bool) (*blockHeader, error)
+	ChainID(context.Context) (string, error)
+	NodeVersion(context.Context) (*nodeVersion, error)
+	Close() error
+}
+
+// newAccessClient connects to the access node of the network, it can be replaced in tests.
+var newAccessClient = func(network config.Network) (accessClient, error) {
+	credentials := grpc.WithTransportCredentials(insecure.NewCredentials())
+	if network.Key != "" {
+		secure, err := grpcutils.SecureGRPCDialOpt(strings.TrimPrefix(network.Key, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create secure GRPC dial options with network key \"%s\": %w", network.Key, err)
+		}
+		credentials = secure
+	}
+
+	conn, err := grpc.Dial(network.Host, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to host %s: %w", network.Host, err)
+	}
+
+	return &grpcAccessClient{
+		conn:   conn,
+		client: access.NewAccessAPIClient(conn),
+	}, nil
+}
+
+type grpcAccessClient struct {
+	conn   *grpc.ClientConn
+	client access.AccessAPIClient
+}
+
+func (c *grpcAccessClient) Ping(ctx context.Context) error {
+	_, err := c.client.Ping(ctx, &access.PingRequest{})
+	return err
+}
+
+func (c *grpcAccessClient) LatestBlockHeader(ctx context.Context, sealed bool) (*blockHeader, error) {
+	res, err := c.client.GetLatestBlockHeader(ctx, &access.GetLatestBlockHeaderRequest{IsSealed: sealed})
+	if err != nil {
+		return nil, err
+	}
+
+	return &blockHeader{
+		height:    res.GetBlock().GetHeight(),
+		timestamp: res.GetBlock().GetTimestamp().AsTime(),
+	}, nil
+}
+
+func (c *grpcAccessClient) ChainID(ctx context.Context) (string, error) {
+	res, err := c.client.GetNetworkParameters(ctx, &access.GetNetworkParametersRequest{})
+	if err != nil {
+		return "", err
+	}
+
+	return res.GetChainId(), nil
+}
+
+func (c *grpcAccessClient) NodeVersion(ctx context.Context) (*nodeVersion, error) {
+	res, err := c.client.GetNodeVersionInfo(ctx, &access.GetNodeVersionInfoRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	info := res.GetInfo()
+	return &nodeVersion{
+		semver:               info.GetSemver(),
+		commit:               info.GetCommit(),
+		protocolVersion:      info.GetProtocolVersion(),
+		sporkRootBlockHeight: info.GetSporkRootBlockHeight(),
+		nodeRootBlockHeight:  info.GetNodeRootBlockHeight(),
+	}, nil
+}
+
+func (c *grpcAccessClient) Close() error {
+	return c.conn.Close()
+}
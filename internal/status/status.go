@@ -20,11 +20,20 @@ package status
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/config"
 	"github.com/onflow/flowkit/output"
 
 	"github.com/onflow/flow-cli/internal/command"
@@ -32,6 +41,10 @@ import (
 )
 
 type flagsStatus struct {
+	All      bool   `default:"false" flag:"all" info:"Check the status of all networks in the configuration"`
+	Watch    bool   `default:"false" flag:"watch" info:"Continuously refresh the status until interrupted"`
+	Interval string `default:"5s" flag:"interval" info:"Time between refreshes in watch mode"`
+	Timeout  string `default:"10s" flag:"timeout" info:"Maximum time to wait for the access node of each network"`
 }
 
 var statusFlags = flagsStatus{}
@@ -40,6 +53,9 @@ var Command = &command.Command{
 	Cmd: &cobra.Command{
 		Use:   "status",
 		Short: "Display the status of the Flow network",
+		Example: `flow status --network testnet
+
+flow status --all --watch`,
 	},
 	Flags: &statusFlags,
 	RunS:  status,
@@ -47,24 +63,160 @@ var Command = &command.Command{
 
 func status(
 	_ []string,
-	_ command.GlobalFlags,
+	globalFlags command.GlobalFlags,
 	_ output.Logger,
 	flow flowkit.Services,
-	_ *flowkit.State,
+	state *flowkit.State,
 ) (command.Result, error) {
-	err := flow.Ping()
+	timeout, err := time.ParseDuration(statusFlags.Timeout)
+	if err != nil || timeout <= 0 {
+		return nil, fmt.Errorf("invalid timeout %s, must be a positive duration such as 10s", statusFlags.Timeout)
+	}
+
+	networks := []config.Network{flow.Network()}
+	if statusFlags.All {
+		networks = *state.Networks()
+	}
+
+	if !statusFlags.Watch {
+		return checkNetworks(networks, timeout), nil
+	}
+
+	// each refresh is written as it is checked, so the result can't be saved or filtered
+	if globalFlags.Save != "" || globalFlags.Filter != "" {
+		return nil, fmt.Errorf("save and filter flags are not supported in watch mode, redirect the output instead")
+	}
+
+	interval, err := time.ParseDuration(statusFlags.Interval)
+	if err != nil || interval <= 0 {
+		return nil, fmt.Errorf("invalid interval %s, must be a positive duration such as 5s", statusFlags.Interval)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	err = watchStatus(ctx, os.Stdout, globalFlags.Format, interval, func() command.Result {
+		return checkNetworks(networks, timeout)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// watchStatus writes the checked status in the output format every interval until the context is cancelled.
+//
+// The JSON and inline formats write a line for each refresh, while the default format refreshes the
+// status in place.
+func watchStatus(
+	ctx context.Context,
+	w io.Writer,
+	format string,
+	interval time.Duration,
+	check func() command.Result,
+) error {
+	for {
+		res := check()
+
+		switch format {
+		case command.FormatJSON:
+			data, err := json.Marshal(res.JSON())
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(w, "%s\n", data)
+		case command.FormatInline:
+			_, _ = fmt.Fprintf(w, "%s %s\n", time.Now().Format(time.RFC3339), res.Oneliner())
+		default:
+			// clear the terminal so the status is refreshed in place
+			_, _ = fmt.Fprintf(w, "\033[H\033[2J%s\n\n%s", time.Now().Format(time.RFC3339), res.String())
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// checkNetworks checks the status of the networks concurrently.
+func checkNetworks(networks []config.Network, timeout time.Duration) command.Result {
+	results := make([]*result, len(networks))
+	var wg sync.WaitGroup
+	for i, network := range networks {
+		wg.Add(1)
+		go func(i int, network config.Network) {
+			defer wg.Done()
+			results[i] = checkNetwork(network, timeout)
+		}(i, network)
+	}
+	wg.Wait()
+
+	if len(results) == 1 {
+		return results[0]
+	}
+
+	return &allResult{results: results}
+}
+
+// checkNetwork pings the access node of the network and collects the chain details,
+// details not supported by the access node are left empty.
+func checkNetwork(network config.Network, timeout time.Duration) *result {
+	r := &result{
+		network:    network.Name,
+		accessNode: network.Host,
+	}
+
+	client, err := newAccessClient(network)
+	if err != nil {
+		r.err = err
+		return r
+	}
+	defer func() { _ = client.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// the connection is established lazily by the first request, so the latency
+	// is measured on a second ping to leave out the connection setup
+	if r.err = client.Ping(ctx); r.err != nil {
+		return r
+	}
+
+	start := time.Now()
+	r.err = client.Ping(ctx)
+	r.latency = time.Since(start)
+	if r.err != nil {
+		return r
+	}
+
+	if header, err := client.LatestBlockHeader(ctx, true); err == nil {
+		r.sealed = header
+	}
+	if header, err := client.LatestBlockHeader(ctx, false); err == nil {
+		r.finalized = header
+	}
+	if chainID, err := client.ChainID(ctx); err == nil {
+		r.chainID = chainID
+	}
+	if version, err := client.NodeVersion(ctx); err == nil {
+		r.version = version
+	}
 
-	return &result{
-		network:    flow.Network().Name,
-		accessNode: flow.Network().Host,
-		err:        err,
-	}, nil
+	return r
 }
 
 type result struct {
 	network    string
 	accessNode string
 	err        error
+	latency    time.Duration
+	sealed     *blockHeader
+	finalized  *blockHeader
+	chainID    string
+	version    *nodeVersion
 }
 
 // getStatus returns string representation for Flow network status.
@@ -94,6 +246,15 @@ func (r *result) getIcon() string {
 	return output.StopEmoji()
 }
 
+// blockAge returns the time since the latest finalized block was proposed.
+func (r *result) blockAge() time.Duration {
+	if r.finalized == nil {
+		return 0
+	}
+
+	return time.Since(r.finalized.timestamp).Round(time.Millisecond)
+}
+
 // String converts result to a string.
 func (r *result) String() string {
 	var b bytes.Buffer
@@ -103,18 +264,64 @@ func (r *result) String() string {
 	_, _ = fmt.Fprintf(writer, "Network:\t %s\n", r.network)
 	_, _ = fmt.Fprintf(writer, "Access Node:\t %s\n", r.accessNode)
 
+	if r.err == nil {
+		_, _ = fmt.Fprintf(writer, "Latency:\t %s\n", r.latency.Round(time.Millisecond))
+		if r.chainID != "" {
+			_, _ = fmt.Fprintf(writer, "Chain ID:\t %s\n", r.chainID)
+		}
+		if r.sealed != nil {
+			_, _ = fmt.Fprintf(writer, "Latest Sealed Height:\t %d\n", r.sealed.height)
+		}
+		if r.finalized != nil {
+			_, _ = fmt.Fprintf(writer, "Latest Finalized Height:\t %d\n", r.finalized.height)
+			_, _ = fmt.Fprintf(writer, "Block Age:\t %s\n", r.blockAge())
+		}
+		if r.version != nil {
+			_, _ = fmt.Fprintf(writer, "Spork Root Height:\t %d\n", r.version.sporkRootBlockHeight)
+			_, _ = fmt.Fprintf(writer, "Node Root Height:\t %d\n", r.version.nodeRootBlockHeight)
+			_, _ = fmt.Fprintf(writer, "Node Version:\t %s (%s)\n", r.version.semver, r.version.commit)
+			_, _ = fmt.Fprintf(writer, "Protocol Version:\t %d\n", r.version.protocolVersion)
+		}
+	} else {
+		_, _ = fmt.Fprintf(writer, "Error:\t %s\n", r.err)
+	}
+
 	_ = writer.Flush()
 	return b.String()
 }
 
 // JSON converts result to a JSON.
 func (r *result) JSON() any {
-	result := make(map[string]string)
+	result := make(map[string]any)
 
 	result["network"] = r.network
 	result["accessNode"] = r.accessNode
 	result["status"] = r.getStatus()
 
+	if r.err != nil {
+		result["error"] = r.err.Error()
+		return result
+	}
+
+	result["latencyMs"] = r.latency.Milliseconds()
+	if r.chainID != "" {
+		result["chainId"] = r.chainID
+	}
+	if r.sealed != nil {
+		result["sealedHeight"] = r.sealed.height
+	}
+	if r.finalized != nil {
+		result["finalizedHeight"] = r.finalized.height
+		result["blockAgeMs"] = r.blockAge().Milliseconds()
+	}
+	if r.version != nil {
+		result["sporkRootHeight"] = r.version.sporkRootBlockHeight
+		result["nodeRootHeight"] = r.version.nodeRootBlockHeight
+		result["nodeVersion"] = r.version.semver
+		result["nodeCommit"] = r.version.commit
+		result["protocolVersion"] = r.version.protocolVersion
+	}
+
 	return result
 }
 
@@ -122,3 +329,62 @@ func (r *result) JSON() any {
 func (r *result) Oneliner() string {
 	return r.getStatus()
 }
+
+// allResult is the status of all the networks in the configuration.
+type allResult struct {
+	results []*result
+}
+
+func (r *allResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Network\tStatus\tLatency\tSealed\tFinalized\tBlock Age\tChain ID\tVersion\tAccess Node\n")
+	for _, res := range r.results {
+		latency, sealed, finalized, age, version := "-", "-", "-", "-", "-"
+		if res.err == nil {
+			latency = res.latency.Round(time.Millisecond).String()
+		}
+		if res.sealed != nil {
+			sealed = fmt.Sprintf("%d", res.sealed.height)
+		}
+		if res.finalized != nil {
+			finalized = fmt.Sprintf("%d", res.finalized.height)
+			age = res.blockAge().String()
+		}
+		if res.version != nil {
+			version = res.version.semver
+		}
+		chainID := res.chainID
+		if chainID == "" {
+			chainID = "-"
+		}
+
+		_, _ = fmt.Fprintf(
+			writer,
+			"%s\t%s %s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			res.network, res.getIcon(), res.getColoredStatus(), latency, sealed, finalized, age, chainID, version, res.accessNode,
+		)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *allResult) JSON() any {
+	results := make([]any, 0, len(r.results))
+	for _, res := range r.results {
+		results = append(results, res.JSON())
+	}
+
+	return results
+}
+
+func (r *allResult) Oneliner() string {
+	statuses := make([]string, 0, len(r.results))
+	for _, res := range r.results {
+		statuses = append(statuses, fmt.Sprintf("%s: %s", res.network, res.getStatus()))
+	}
+
+	return strings.Join(statuses, ", ")
+}
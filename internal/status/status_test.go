@@ -0,0 +1,176 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package status
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flowkit/config"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type fakeAccessClient struct {
+	offline bool
+	version bool
+	connect time.Duration
+	pings   int
+}
+
+func (c *fakeAccessClient) Ping(context.Context) error {
+	if c.offline {
+		return fmt.Errorf("connection refused")
+	}
+	if c.pings == 0 {
+		time.Sleep(c.connect)
+	}
+	c.pings++
+	return nil
+}
+
+func (c *fakeAccessClient) LatestBlockHeader(_ context.Context, sealed bool) (*blockHeader, error) {
+	if sealed {
+		return &blockHeader{height: 100, timestamp: time.Now().Add(-10 * time.Second)}, nil
+	}
+	return &blockHeader{height: 105, timestamp: time.Now().Add(-time.Second)}, nil
+}
+
+func (c *fakeAccessClient) ChainID(context.Context) (string, error) {
+	return "flow-testnet", nil
+}
+
+func (c *fakeAccessClient) NodeVersion(context.Context) (*nodeVersion, error) {
+	if !c.version {
+		return nil, fmt.Errorf("unimplemented")
+	}
+	return &nodeVersion{semver: "v0.33.0", commit: "abc", sporkRootBlockHeight: 50, nodeRootBlockHeight: 60}, nil
+}
+
+func (c *fakeAccessClient) Close() error {
+	return nil
+}
+
+func Test_Status(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	statusFlags.Timeout = "10s"
+
+	defer func(original func(config.Network) (accessClient, error)) { newAccessClient = original }(newAccessClient)
+	newAccessClient = func(network config.Network) (accessClient, error) {
+		return &fakeAccessClient{
+			offline: network.Name == config.MainnetNetwork.Name,
+			version: network.Name == config.TestnetNetwork.Name,
+		}, nil
+	}
+
+	t.Run("Success network", func(t *testing.T) {
+		statusFlags.All = false
+		srv.Network.Return(config.TestnetNetwork)
+
+		res, err := status(nil, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		r := res.(*result)
+		assert.Equal(t, "ONLINE", r.Oneliner())
+		assert.Equal(t, uint64(100), r.sealed.height)
+		assert.Equal(t, uint64(105), r.finalized.height)
+		assert.Equal(t, "flow-testnet", r.chainID)
+		assert.Equal(t, uint64(50), r.version.sporkRootBlockHeight)
+		assert.Contains(t, r.String(), "Spork Root Height")
+		assert.Equal(t, uint64(105), r.JSON().(map[string]any)["finalizedHeight"])
+	})
+
+	t.Run("Success latency excludes connection setup", func(t *testing.T) {
+		client := &fakeAccessClient{connect: 200 * time.Millisecond}
+		defer func(original func(config.Network) (accessClient, error)) { newAccessClient = original }(newAccessClient)
+		newAccessClient = func(config.Network) (accessClient, error) {
+			return client, nil
+		}
+
+		r := checkNetwork(config.TestnetNetwork, time.Second)
+		require.NoError(t, r.err)
+		assert.Equal(t, 2, client.pings)
+		assert.Less(t, r.latency, client.connect)
+	})
+
+	t.Run("Success all networks", func(t *testing.T) {
+		statusFlags.All = true
+		defer func() { statusFlags.All = false }()
+
+		res, err := status(nil, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		r := res.(*allResult)
+		require.Len(t, r.results, 4)
+		assert.Equal(t, "emulator: ONLINE, testing: ONLINE, testnet: ONLINE, mainnet: OFFLINE", r.Oneliner())
+		assert.Nil(t, r.results[0].version)
+		assert.EqualError(t, r.results[3].err, "connection refused")
+	})
+
+	t.Run("Success watch", func(t *testing.T) {
+		srv.Network.Return(config.TestnetNetwork)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		checks := 0
+		var out bytes.Buffer
+		err := watchStatus(ctx, &out, command.FormatJSON, time.Millisecond, func() command.Result {
+			checks++
+			if checks == 3 {
+				cancel()
+			}
+			return checkNetworks([]config.Network{config.TestnetNetwork}, time.Second)
+		})
+		require.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 3)
+		for _, line := range lines {
+			var refresh map[string]any
+			require.NoError(t, json.Unmarshal([]byte(line), &refresh))
+			assert.Equal(t, "testnet", refresh["network"])
+			assert.Equal(t, "ONLINE", refresh["status"])
+		}
+	})
+
+	t.Run("Fail watch with save", func(t *testing.T) {
+		statusFlags.Watch = true
+		defer func() { statusFlags.Watch = false }()
+
+		_, err := status(nil, command.GlobalFlags{Save: "status.json"}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "save and filter flags are not supported in watch mode, redirect the output instead")
+	})
+
+	t.Run("Fail invalid timeout", func(t *testing.T) {
+		statusFlags.Timeout = "soon"
+		defer func() { statusFlags.Timeout = "10s" }()
+
+		_, err := status(nil, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "invalid timeout soon, must be a positive duration such as 10s")
+	})
+}
@@ -21,20 +21,18 @@ package super
 import (
 	"context"
 	"fmt"
-	"os"
 
 	"github.com/onflow/flixkit-go/flixkit"
 
-	"github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flowkit"
-	"github.com/onflow/flowkit/config"
 	"github.com/onflow/flowkit/output"
 
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/scripts"
 	"github.com/onflow/flow-cli/internal/transactions"
+	"github.com/onflow/flow-cli/internal/util"
 )
 
 type flixFlags struct {
@@ -216,7 +214,7 @@ func generateFlixCmd(
 	flags flixFlags,
 ) (result command.Result, err error) {
 	cadenceFile := args[0]
-	depContracts := getDeployedContracts(state)
+	depContracts := util.DeployedContracts(state)
 	if cadenceFile == "" {
 		return nil, fmt.Errorf("no cadence code found")
 	}
@@ -257,61 +255,3 @@ func (fr *flixResult) String() string {
 func (fr *flixResult) Oneliner() string {
 	return fr.result
 }
-
-func getDeployedContracts(state *flowkit.State) flixkit.ContractInfos {
-	allContracts := make(flixkit.ContractInfos)
-	depNetworks := make([]string, 0)
-	accountAddresses := make(map[string]string)
-	// get all configured networks in flow.json
-	for _, n := range *state.Networks() {
-		depNetworks = append(depNetworks, n.Name)
-	}
-
-	// get account addresses
-	for _, a := range *state.Accounts() {
-		accountAddresses[a.Name] = a.Address.Hex()
-	}
-
-	for _, d := range *state.Deployments() {
-		addr := accountAddresses[d.Account]
-		for _, c := range d.Contracts {
-			if _, ok := allContracts[c.Name]; !ok {
-				allContracts[c.Name] = make(flixkit.NetworkAddressMap)
-			}
-			allContracts[c.Name][d.Network] = addr
-		}
-	}
-
-	// get all deployed and alias contracts for configured networks
-	for _, network := range depNetworks {
-		cfg := config.Network{Name: network}
-		contracts, err := state.DeploymentContractsByNetwork(cfg)
-		if err != nil {
-			continue
-		}
-		for _, c := range contracts {
-			if _, ok := allContracts[c.Name]; !ok {
-				allContracts[c.Name] = make(flixkit.NetworkAddressMap)
-			}
-			allContracts[c.Name][network] = c.AccountAddress.Hex()
-		}
-		locAliases := state.AliasesForNetwork(cfg)
-		for name, addr := range locAliases {
-			address := flow.BytesToAddress([]byte(addr))
-			if isPath(name) {
-				continue
-			}
-			if _, ok := allContracts[name]; !ok {
-				allContracts[name] = make(flixkit.NetworkAddressMap)
-			}
-			allContracts[name][network] = address.Hex()
-		}
-	}
-
-	return allContracts
-}
-
-func isPath(path string) bool {
-	_, err := os.Stat(path)
-	return err == nil
-}
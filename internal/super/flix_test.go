@@ -229,3 +229,51 @@ func Test_GenerateFlixPrefill(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 }
+
+func Test_GenerateFlixAliases(t *testing.T) {
+	logger := output.NewStdoutLogger(output.NoneLog)
+	srv := mocks.DefaultMockServices()
+	cadenceFile := "cadence.cdc"
+
+	configJson := []byte(`{
+		"contracts": {
+			"FungibleToken": {
+				"source": "./FungibleToken.cdc",
+				"aliases": {
+					"testnet": "9a0766d93b6608b7",
+					"mainnet": "0xf233dcee88fe0abe"
+				}
+			}
+		},
+		"networks": {
+			"testnet": "access.devnet.nodes.onflow.org:9000",
+			"mainnet": "access.mainnet.nodes.onflow.org:9000"
+		}
+	}`)
+
+	af := afero.Afero{Fs: afero.NewMemMapFs()}
+	err := afero.WriteFile(af.Fs, "flow.json", configJson, 0644)
+	assert.NoError(t, err)
+	err = afero.WriteFile(af.Fs, cadenceFile, []byte(CADENCE_SCRIPT), 0644)
+	assert.NoError(t, err)
+	state, err := flowkit.Load([]string{"flow.json"}, af)
+	assert.NoError(t, err)
+
+	// aliases are hex addresses with or without the prefix
+	aliases := flixkit.NetworkAddressMap{
+		"testnet": "9a0766d93b6608b7",
+		"mainnet": "f233dcee88fe0abe",
+	}
+	contractInfos := mock.MatchedBy(func(infos flixkit.ContractInfos) bool {
+		return assert.Equal(t, aliases, infos["FungibleToken"])
+	})
+
+	mockFlixService := new(MockFlixService)
+	ctx := context.Background()
+	mockFlixService.On("CreateTemplate", ctx, contractInfos, CADENCE_SCRIPT, "").Return(TEMPLATE_STR, nil)
+
+	result, err := generateFlixCmd([]string{cadenceFile}, command.GlobalFlags{}, logger, srv.Mock, state, mockFlixService, flixFlags{})
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	mockFlixService.AssertExpectations(t)
+}
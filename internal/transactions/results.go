@@ -0,0 +1,182 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transactions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flowkit"
+
+	"github.com/onflow/flow-cli/internal/events"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// resultsWorkers is the number of transactions fetched concurrently.
+const resultsWorkers = 10
+
+// errorSummaryLength is the maximum length of the error shown in the results table.
+const errorSummaryLength = 80
+
+// Result is a transaction with the result it produced.
+type Result struct {
+	Transaction *flow.Transaction
+	Result      *flow.TransactionResult
+}
+
+// Results are the transactions of a block or a collection in the execution order.
+type Results []Result
+
+// NewResults pairs the transactions with their results, which must be in the same order.
+func NewResults(txs []*flow.Transaction, txResults []*flow.TransactionResult) (Results, error) {
+	if len(txs) != len(txResults) {
+		return nil, fmt.Errorf("got %d transactions but %d transaction results", len(txs), len(txResults))
+	}
+
+	results := make(Results, len(txs))
+	for i, tx := range txs {
+		results[i] = Result{Transaction: tx, Result: txResults[i]}
+	}
+
+	return results, nil
+}
+
+// GetResults fetches the transactions with the provided IDs and their results concurrently.
+func GetResults(flow flowkit.Services, ids []flow.Identifier) (Results, error) {
+	results := make(Results, len(ids))
+	err := util.RunWorkers(len(ids), resultsWorkers, func(i int) error {
+		tx, result, err := flow.GetTransactionByID(context.Background(), ids[i], false)
+		if err != nil {
+			return fmt.Errorf("failed to get transaction %s: %w", ids[i], err)
+		}
+		results[i] = Result{Transaction: tx, Result: result}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (r Result) status() string {
+	if r.Result == nil {
+		return "UNKNOWN"
+	}
+
+	return r.Result.Status.String()
+}
+
+func (r Result) events() []flow.Event {
+	if r.Result == nil {
+		return nil
+	}
+
+	return r.Result.Events
+}
+
+// errorSummary returns the first line of the transaction error shortened to fit the table.
+func (r Result) errorSummary() string {
+	if r.Result == nil || r.Result.Error == nil {
+		return ""
+	}
+
+	summary, _, _ := strings.Cut(strings.TrimSpace(r.Result.Error.Error()), "\n")
+	if len(summary) > errorSummaryLength {
+		summary = summary[:errorSummaryLength-3] + "..."
+	}
+
+	return summary
+}
+
+// TableJSON returns the transaction summaries.
+func (r Results) TableJSON() []any {
+	results := make([]any, 0, len(r))
+	for _, result := range r {
+		tx := map[string]any{
+			"id":     result.Transaction.ID().String(),
+			"payer":  result.Transaction.Payer.String(),
+			"status": result.status(),
+			"events": len(result.events()),
+		}
+		if result.Result != nil && result.Result.Error != nil {
+			tx["error"] = result.Result.Error.Error()
+		}
+		results = append(results, tx)
+	}
+
+	return results
+}
+
+// WriteTable writes a compact table of the transactions with the status, event count and error summary.
+func (r Results) WriteTable(writer io.Writer) {
+	_, _ = fmt.Fprintf(writer, "    ID\tPayer\tStatus\tEvents\tError\n")
+	for _, result := range r {
+		_, _ = fmt.Fprintf(
+			writer,
+			"    %s\t%s\t%s\t%d\t%s\n",
+			result.Transaction.ID(),
+			result.Transaction.Payer.Hex(),
+			result.status(),
+			len(result.events()),
+			result.errorSummary(),
+		)
+	}
+}
+
+// EventsJSON returns the events grouped by the transaction that emitted them.
+func (r Results) EventsJSON() []any {
+	transactions := make([]any, 0, len(r))
+	for _, result := range r {
+		txEvents := make([]any, 0, len(result.events()))
+		for _, event := range result.events() {
+			txEvents = append(txEvents, map[string]any{
+				"index":  event.EventIndex,
+				"type":   event.Type,
+				"values": json.RawMessage(event.Payload),
+			})
+		}
+
+		transactions = append(transactions, map[string]any{
+			"transactionId": result.Transaction.ID().String(),
+			"events":        txEvents,
+		})
+	}
+
+	return transactions
+}
+
+// WriteEvents writes all the events grouped by the transaction that emitted them.
+func (r Results) WriteEvents(writer io.Writer) {
+	for _, result := range r {
+		_, _ = fmt.Fprintf(writer, "Events Transaction %s:", result.Transaction.ID())
+
+		e := events.EventResult{Events: result.events()}
+		eventsOutput := e.String()
+		if eventsOutput == "" {
+			eventsOutput = " None\n"
+		}
+		_, _ = fmt.Fprintf(writer, "%s\n", eventsOutput)
+	}
+}
@@ -25,12 +25,17 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"text/tabwriter"
 
+	"github.com/onflow/flixkit-go/flixkit"
 	flowsdk "github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
 
 	"github.com/onflow/flowkit"
+	"github.com/onflow/flowkit/config"
+	"github.com/onflow/flowkit/gateway"
+	"github.com/onflow/flowkit/output"
 )
 
 const EnvPrefix = "FLOW"
@@ -82,6 +87,20 @@ func GetAddressNetwork(address flowsdk.Address) (flowsdk.ChainID, error) {
 	return "", fmt.Errorf("address not valid for any known chain: %s", address)
 }
 
+// ServicesForNetwork returns the services connected to the network, reusing the current services if possible.
+func ServicesForNetwork(flow flowkit.Services, state *flowkit.State, network *config.Network) (flowkit.Services, error) {
+	if network.Name == flow.Network().Name {
+		return flow, nil
+	}
+
+	gw, err := gateway.NewGrpcGateway(*network)
+	if err != nil {
+		return nil, err
+	}
+
+	return flowkit.NewFlowkit(state, *network, gw, output.NewStdoutLogger(output.NoneLog)), nil
+}
+
 func CreateTabWriter(b *bytes.Buffer) *tabwriter.Writer {
 	return tabwriter.NewWriter(b, 0, 8, 1, '\t', tabwriter.AlignRight)
 }
@@ -101,6 +120,47 @@ func ValidateECDSAP256Pub(key string) error {
 	return nil
 }
 
+// RunWorkers calls run for every index up to the count using a pool of workers,
+// no new indexes are started after the first error which is returned.
+func RunWorkers(count int, workers int, run func(i int) error) error {
+	indexes := make(chan int)
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				err := run(index)
+
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := 0; i < count; i++ {
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			break
+		}
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return firstErr
+}
+
 func removeFromStringArray(s []string, el string) []string {
 	for i, v := range s {
 		if v == el {
@@ -115,3 +175,62 @@ func removeFromStringArray(s []string, el string) []string {
 func NormalizeLineEndings(s string) string {
 	return strings.ReplaceAll(s, "\r\n", "\n")
 }
+
+// DeployedContracts returns the addresses of the contracts deployed or aliased on each network in the configuration.
+func DeployedContracts(state *flowkit.State) flixkit.ContractInfos {
+	allContracts := make(flixkit.ContractInfos)
+	depNetworks := make([]string, 0)
+	accountAddresses := make(map[string]string)
+	// get all configured networks in flow.json
+	for _, n := range *state.Networks() {
+		depNetworks = append(depNetworks, n.Name)
+	}
+
+	// get account addresses
+	for _, a := range *state.Accounts() {
+		accountAddresses[a.Name] = a.Address.Hex()
+	}
+
+	for _, d := range *state.Deployments() {
+		addr := accountAddresses[d.Account]
+		for _, c := range d.Contracts {
+			if _, ok := allContracts[c.Name]; !ok {
+				allContracts[c.Name] = make(flixkit.NetworkAddressMap)
+			}
+			allContracts[c.Name][d.Network] = addr
+		}
+	}
+
+	// get all deployed and alias contracts for configured networks
+	for _, network := range depNetworks {
+		cfg := config.Network{Name: network}
+		contracts, err := state.DeploymentContractsByNetwork(cfg)
+		if err != nil {
+			continue
+		}
+		for _, c := range contracts {
+			if _, ok := allContracts[c.Name]; !ok {
+				allContracts[c.Name] = make(flixkit.NetworkAddressMap)
+			}
+			allContracts[c.Name][network] = c.AccountAddress.Hex()
+		}
+		locAliases := state.AliasesForNetwork(cfg)
+		for name, addr := range locAliases {
+			address := flowsdk.HexToAddress(addr)
+			if isPath(name) {
+				continue
+			}
+			if _, ok := allContracts[name]; !ok {
+				allContracts[name] = make(flixkit.NetworkAddressMap)
+			}
+			allContracts[name][network] = address.Hex()
+		}
+	}
+
+	return allContracts
+}
+
+func isPath(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}